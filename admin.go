@@ -2,51 +2,148 @@ package gapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
+	"time"
 )
 
+// UserAuthToken is a login session of a user
+type UserAuthToken struct {
+	Id             int64     `json:"id"`
+	IsActive       bool      `json:"isActive"`
+	ClientIp       string    `json:"clientIp"`
+	Browser        string    `json:"browser"`
+	BrowserVersion string    `json:"browserVersion"`
+	Os             string    `json:"os"`
+	OsVersion      string    `json:"osVersion"`
+	Device         string    `json:"device"`
+	CreatedAt      time.Time `json:"createdAt"`
+	SeenAt         time.Time `json:"seenAt"`
+}
+
 func (c *Client) CreateUser(user User) (int64, error) {
-	id := int64(0)
+	return c.CreateUserContext(context.Background(), user)
+}
+
+// CreateUserContext is like CreateUser but takes a context for cancellation and deadlines.
+func (c *Client) CreateUserContext(ctx context.Context, user User) (int64, error) {
 	data, err := json.Marshal(user)
-	req, err := c.newRequest("POST", "/api/admin/users", nil, bytes.NewBuffer(data))
-	if err != nil {
-		return id, err
-	}
-	resp, err := c.Do(req)
 	if err != nil {
-		return id, err
-	}
-	if resp.StatusCode != 200 {
-		return id, errors.New(resp.Status)
-	}
-	data, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return id, err
+		return 0, err
 	}
 	created := struct {
 		Id int64 `json:"id"`
 	}{}
-	err = json.Unmarshal(data, &created)
-	if err != nil {
-		return id, err
-	}
+	err = c.request(ctx, "POST", "/api/admin/users", nil, bytes.NewBuffer(data), &created)
 	return created.Id, err
 }
 
 func (c *Client) DeleteUser(id int64) error {
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/api/admin/users/%d", id), nil, nil)
+	return c.DeleteUserContext(context.Background(), id)
+}
+
+// DeleteUserContext is like DeleteUser but takes a context for cancellation and deadlines.
+func (c *Client) DeleteUserContext(ctx context.Context, id int64) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/admin/users/%d", id), nil, nil, nil)
+}
+
+// UpdateUserPassword sets the password of the user with the given id
+func (c *Client) UpdateUserPassword(id int64, password string) error {
+	return c.UpdateUserPasswordContext(context.Background(), id, password)
+}
+
+// UpdateUserPasswordContext is like UpdateUserPassword but takes a context for cancellation and deadlines.
+func (c *Client) UpdateUserPasswordContext(ctx context.Context, id int64, password string) error {
+	data, err := json.Marshal(map[string]string{
+		"password": password,
+	})
 	if err != nil {
 		return err
 	}
-	resp, err := c.Do(req)
+	return c.request(ctx, "PUT", fmt.Sprintf("/api/admin/users/%d/password", id), nil, bytes.NewBuffer(data), nil)
+}
+
+// UpdateUserPermissions grants or revokes the Grafana admin permission of the user with the given id
+func (c *Client) UpdateUserPermissions(id int64, isAdmin bool) error {
+	return c.UpdateUserPermissionsContext(context.Background(), id, isAdmin)
+}
+
+// UpdateUserPermissionsContext is like UpdateUserPermissions but takes a context for cancellation and deadlines.
+func (c *Client) UpdateUserPermissionsContext(ctx context.Context, id int64, isAdmin bool) error {
+	data, err := json.Marshal(map[string]bool{
+		"isGrafanaAdmin": isAdmin,
+	})
 	if err != nil {
 		return err
 	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
+	return c.request(ctx, "PUT", fmt.Sprintf("/api/admin/users/%d/permissions", id), nil, bytes.NewBuffer(data), nil)
+}
+
+// EnableUser enables the user with the given id
+func (c *Client) EnableUser(id int64) error {
+	return c.EnableUserContext(context.Background(), id)
+}
+
+// EnableUserContext is like EnableUser but takes a context for cancellation and deadlines.
+func (c *Client) EnableUserContext(ctx context.Context, id int64) error {
+	return c.request(ctx, "POST", fmt.Sprintf("/api/admin/users/%d/enable", id), nil, nil, nil)
+}
+
+// DisableUser disables the user with the given id, a disabled user can't log in
+func (c *Client) DisableUser(id int64) error {
+	return c.DisableUserContext(context.Background(), id)
+}
+
+// DisableUserContext is like DisableUser but takes a context for cancellation and deadlines.
+func (c *Client) DisableUserContext(ctx context.Context, id int64) error {
+	return c.request(ctx, "POST", fmt.Sprintf("/api/admin/users/%d/disable", id), nil, nil, nil)
+}
+
+// UserAuthTokens lists the login sessions of the user with the given id
+func (c *Client) UserAuthTokens(id int64) ([]UserAuthToken, error) {
+	return c.UserAuthTokensContext(context.Background(), id)
+}
+
+// UserAuthTokensContext is like UserAuthTokens but takes a context for cancellation and deadlines.
+func (c *Client) UserAuthTokensContext(ctx context.Context, id int64) ([]UserAuthToken, error) {
+	tokens := make([]UserAuthToken, 0)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/admin/users/%d/auth-tokens", id), nil, nil, &tokens)
+	return tokens, err
+}
+
+// RevokeUserAuthToken ends the login session tokenId of the user with the given id
+func (c *Client) RevokeUserAuthToken(id, tokenId int64) error {
+	return c.RevokeUserAuthTokenContext(context.Background(), id, tokenId)
+}
+
+// RevokeUserAuthTokenContext is like RevokeUserAuthToken but takes a context for cancellation and deadlines.
+func (c *Client) RevokeUserAuthTokenContext(ctx context.Context, id, tokenId int64) error {
+	data, err := json.Marshal(map[string]int64{
+		"authTokenId": tokenId,
+	})
+	if err != nil {
+		return err
 	}
-	return err
+	return c.request(ctx, "POST", fmt.Sprintf("/api/admin/users/%d/revoke-auth-token", id), nil, bytes.NewBuffer(data), nil)
+}
+
+// LogoutUser ends all login sessions of the user with the given id
+func (c *Client) LogoutUser(id int64) error {
+	return c.LogoutUserContext(context.Background(), id)
+}
+
+// LogoutUserContext is like LogoutUser but takes a context for cancellation and deadlines.
+func (c *Client) LogoutUserContext(ctx context.Context, id int64) error {
+	return c.request(ctx, "POST", fmt.Sprintf("/api/admin/users/%d/logout", id), nil, nil, nil)
+}
+
+// PauseAllAlerts pauses or resumes all legacy alerts of the instance
+func (c *Client) PauseAllAlerts(paused bool) (*PauseAlertResult, error) {
+	return c.PauseAllAlertsContext(context.Background(), paused)
+}
+
+// PauseAllAlertsContext is like PauseAllAlerts but takes a context for cancellation and deadlines.
+func (c *Client) PauseAllAlertsContext(ctx context.Context, paused bool) (*PauseAlertResult, error) {
+	return c.pauseAlerts(ctx, "/api/admin/pause-all-alerts", paused)
 }
@@ -2,11 +2,32 @@ package gapi
 
 import (
 	"testing"
+
+	"github.com/gobs/pretty"
 )
 
 const (
 	createUserJSON = `{"id":1,"message":"User created"}`
 	deleteUserJSON = `{"message":"User deleted"}`
+
+	getUserAuthTokensJSON = `
+[
+  {
+    "id": 361,
+    "isActive": true,
+    "clientIp": "127.0.0.1",
+    "browser": "Chrome",
+    "browserVersion": "72.0",
+    "os": "Linux",
+    "osVersion": "",
+    "device": "Other",
+    "createdAt": "2019-03-05T21:22:54+01:00",
+    "seenAt": "2019-03-06T19:41:06+01:00"
+  }
+]
+`
+
+	pauseAllAlertsJSON = `{"alertsAffected":3,"message":"alerts paused","state":"Paused"}`
 )
 
 func TestCreateUser(t *testing.T) {
@@ -37,3 +58,83 @@ func TestDeleteUser(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestUpdateUserPassword(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"User password updated"}`)
+	defer server.Close()
+
+	if err := client.UpdateUserPassword(2, "new-password"); err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/admin/users/2/password")
+}
+
+func TestUpdateUserPermissions(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"User permissions updated"}`)
+	defer server.Close()
+
+	if err := client.UpdateUserPermissions(2, true); err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/admin/users/2/permissions")
+}
+
+func TestDisableUser(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"User disabled"}`)
+	defer server.Close()
+
+	if err := client.DisableUser(2); err != nil {
+		t.Error(err)
+	}
+	rec.expect("POST", "/api/admin/users/2/disable")
+	if err := client.EnableUser(2); err != nil {
+		t.Error(err)
+	}
+	rec.expect("POST", "/api/admin/users/2/enable")
+}
+
+func TestUserAuthTokens(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getUserAuthTokensJSON)
+	defer server.Close()
+
+	tokens, err := client.UserAuthTokens(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/admin/users/2/auth-tokens")
+
+	t.Log(pretty.PrettyFormat(tokens))
+
+	if len(tokens) != 1 || tokens[0].Id != 361 || !tokens[0].IsActive || tokens[0].SeenAt.Hour() != 19 {
+		t.Error("Not correctly parsing returned auth tokens.")
+	}
+}
+
+func TestRevokeUserAuthToken(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"User auth token revoked"}`)
+	defer server.Close()
+
+	if err := client.RevokeUserAuthToken(2, 361); err != nil {
+		t.Error(err)
+	}
+	rec.expect("POST", "/api/admin/users/2/revoke-auth-token")
+	if err := client.LogoutUser(2); err != nil {
+		t.Error(err)
+	}
+	rec.expect("POST", "/api/admin/users/2/logout")
+}
+
+func TestPauseAllAlerts(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, pauseAllAlertsJSON)
+	defer server.Close()
+
+	result, err := client.PauseAllAlerts(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("POST", "/api/admin/pause-all-alerts")
+
+	if result.State != "Paused" || result.AlertsAffected != 3 {
+		t.Error("Not correctly parsing returned pause message.")
+	}
+}
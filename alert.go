@@ -0,0 +1,143 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// States of legacy alerts
+const (
+	AlertStateAlerting = "alerting"
+	AlertStateOK       = "ok"
+	AlertStateNoData   = "no_data"
+	AlertStatePaused   = "paused"
+	AlertStatePending  = "pending"
+)
+
+// Alert is a legacy dashboard alert
+type Alert struct {
+	ID             int64         `json:"id"`
+	DashboardID    int64         `json:"dashboardId"`
+	DashboardUID   string        `json:"dashboardUid"`
+	DashboardSlug  string        `json:"dashboardSlug"`
+	PanelID        int64         `json:"panelId"`
+	Name           string        `json:"name"`
+	State          string        `json:"state"`
+	NewStateDate   time.Time     `json:"newStateDate"`
+	EvalDate       time.Time     `json:"evalDate"`
+	EvalData       AlertEvalData `json:"evalData"`
+	ExecutionError string        `json:"executionError"`
+	URL            string        `json:"url"`
+}
+
+// AlertEvalData holds the series that matched during the last evaluation
+type AlertEvalData struct {
+	NoData      bool             `json:"noData,omitempty"`
+	EvalMatches []AlertEvalMatch `json:"evalMatches,omitempty"`
+}
+
+// AlertEvalMatch is a series that matched the alert condition, Value is nil for null values
+type AlertEvalMatch struct {
+	Metric string            `json:"metric"`
+	Tags   map[string]string `json:"tags"`
+	Value  *float64          `json:"value"`
+}
+
+// AlertQuery holds the filters of the alerts API, empty fields are not sent
+type AlertQuery struct {
+	DashboardIDs []int64
+	PanelID      int64
+	Query        string
+	States       []string
+	FolderIDs    []int64
+	Limit        int64
+}
+
+func (q AlertQuery) values() url.Values {
+	params := url.Values{}
+	for _, id := range q.DashboardIDs {
+		params.Add("dashboardId", strconv.FormatInt(id, 10))
+	}
+	if q.PanelID > 0 {
+		params.Add("panelId", strconv.FormatInt(q.PanelID, 10))
+	}
+	if q.Query != "" {
+		params.Add("query", q.Query)
+	}
+	for _, state := range q.States {
+		params.Add("state", state)
+	}
+	for _, id := range q.FolderIDs {
+		params.Add("folderId", strconv.FormatInt(id, 10))
+	}
+	if q.Limit > 0 {
+		params.Add("limit", strconv.FormatInt(q.Limit, 10))
+	}
+	return params
+}
+
+// PauseAlertResult is the response of pausing or resuming alerts,
+// AlertID is only set for a single alert and AlertsAffected only for all alerts
+type PauseAlertResult struct {
+	AlertID        int64  `json:"alertId,omitempty"`
+	AlertsAffected int64  `json:"alertsAffected,omitempty"`
+	State          string `json:"state"`
+	Message        string `json:"message"`
+}
+
+// Alerts lists the legacy alerts matching query
+func (c *Client) Alerts(query AlertQuery) ([]Alert, error) {
+	return c.AlertsContext(context.Background(), query)
+}
+
+// AlertsContext is like Alerts but takes a context for cancellation and deadlines.
+func (c *Client) AlertsContext(ctx context.Context, query AlertQuery) ([]Alert, error) {
+	alerts := make([]Alert, 0)
+	err := c.request(ctx, "GET", "/api/alerts", query.values(), nil, &alerts)
+	return alerts, err
+}
+
+// Alert returns the legacy alert with the given id
+func (c *Client) Alert(id int64) (*Alert, error) {
+	return c.AlertContext(context.Background(), id)
+}
+
+// AlertContext is like Alert but takes a context for cancellation and deadlines.
+func (c *Client) AlertContext(ctx context.Context, id int64) (*Alert, error) {
+	result := &Alert{}
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/alerts/%d", id), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// PauseAlert pauses or resumes the legacy alert with the given id
+func (c *Client) PauseAlert(id int64, paused bool) (*PauseAlertResult, error) {
+	return c.PauseAlertContext(context.Background(), id, paused)
+}
+
+// PauseAlertContext is like PauseAlert but takes a context for cancellation and deadlines.
+func (c *Client) PauseAlertContext(ctx context.Context, id int64, paused bool) (*PauseAlertResult, error) {
+	return c.pauseAlerts(ctx, fmt.Sprintf("/api/alerts/%d/pause", id), paused)
+}
+
+func (c *Client) pauseAlerts(ctx context.Context, path string, paused bool) (*PauseAlertResult, error) {
+	data, err := json.Marshal(map[string]bool{
+		"paused": paused,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &PauseAlertResult{}
+	err = c.request(ctx, "POST", path, nil, bytes.NewBuffer(data), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
@@ -0,0 +1,279 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ExpressionDatasourceUID is the datasource UID of server side expressions
+const ExpressionDatasourceUID = "__expr__"
+
+// Formats of the alerting provisioning export
+const (
+	AlertingExportFormatYAML = "yaml"
+	AlertingExportFormatJSON = "json"
+	AlertingExportFormatHCL  = "hcl"
+)
+
+// AlertRule is a unified alerting rule of the provisioning API
+type AlertRule struct {
+	ID           int64             `json:"id,omitempty"`
+	UID          string            `json:"uid,omitempty"`
+	OrgID        int64             `json:"orgID"`
+	FolderUID    string            `json:"folderUID"`
+	RuleGroup    string            `json:"ruleGroup"`
+	Title        string            `json:"title"`
+	Condition    string            `json:"condition"`
+	Data         []*AlertRuleQuery `json:"data"`
+	Updated      *time.Time        `json:"updated,omitempty"`
+	NoDataState  string            `json:"noDataState"`
+	ExecErrState string            `json:"execErrState"`
+	// For is the pending period as duration string, e.g. 5m
+	For         string            `json:"for"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	IsPaused    bool              `json:"isPaused"`
+	Provenance  string            `json:"provenance,omitempty"`
+}
+
+// AlertRuleQuery is a query or an expression evaluated by an alert rule
+type AlertRuleQuery struct {
+	RefID             string            `json:"refId"`
+	QueryType         string            `json:"queryType"`
+	RelativeTimeRange RelativeTimeRange `json:"relativeTimeRange"`
+	DatasourceUID     string            `json:"datasourceUid"`
+	// Model is the datasource specific query or an ExpressionModel,
+	// use DecodeModel to read it into a typed struct
+	Model interface{} `json:"model"`
+}
+
+// RelativeTimeRange is the time range of an alert rule query in seconds before now
+type RelativeTimeRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// ExpressionModel is the model of a server side expression, Type is one of
+// math, reduce, resample, threshold or classic_conditions
+type ExpressionModel struct {
+	RefID       string                `json:"refId,omitempty"`
+	Type        string                `json:"type"`
+	Expression  string                `json:"expression,omitempty"`
+	Reducer     string                `json:"reducer,omitempty"`
+	Window      string                `json:"window,omitempty"`
+	Downsampler string                `json:"downsampler,omitempty"`
+	Upsampler   string                `json:"upsampler,omitempty"`
+	Conditions  []ExpressionCondition `json:"conditions,omitempty"`
+}
+
+// ExpressionCondition is a condition of a threshold or classic_conditions expression
+type ExpressionCondition struct {
+	Type      string             `json:"type,omitempty"`
+	Evaluator ConditionEvaluator `json:"evaluator"`
+	Operator  *ConditionType     `json:"operator,omitempty"`
+	Query     *ConditionQuery    `json:"query,omitempty"`
+	Reducer   *ConditionType     `json:"reducer,omitempty"`
+}
+
+// ConditionEvaluator compares a value against Params, Type is e.g. gt, lt or within_range
+type ConditionEvaluator struct {
+	Type   string    `json:"type"`
+	Params []float64 `json:"params"`
+}
+
+// ConditionType is the operator (and, or) or the reducer (avg, last, ...) of a condition
+type ConditionType struct {
+	Type string `json:"type"`
+}
+
+// ConditionQuery references the queries a condition is evaluated on
+type ConditionQuery struct {
+	Params []string `json:"params"`
+}
+
+// NewExpressionQuery returns an alert rule query evaluating the given expression
+func NewExpressionQuery(refID string, model ExpressionModel) *AlertRuleQuery {
+	model.RefID = refID
+	return &AlertRuleQuery{
+		RefID:         refID,
+		DatasourceUID: ExpressionDatasourceUID,
+		Model:         model,
+	}
+}
+
+// DecodeModel decodes the model of the query into v
+func (q *AlertRuleQuery) DecodeModel(v interface{}) error {
+	data, err := json.Marshal(q.Model)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// AlertRuleGroup is a group of alert rules in a folder, evaluated every Interval seconds
+type AlertRuleGroup struct {
+	Title     string      `json:"title"`
+	FolderUID string      `json:"folderUid"`
+	Interval  int64       `json:"interval"`
+	Rules     []AlertRule `json:"rules"`
+}
+
+// WithProvenanceDisabled returns a copy of the client that sends the
+// X-Disable-Provenance header, resources provisioned with it stay
+// editable in the Grafana UI
+func (c *Client) WithProvenanceDisabled() *Client {
+	scoped := *c
+	scoped.headers = http.Header{}
+	for key, values := range c.headers {
+		scoped.headers[key] = append([]string(nil), values...)
+	}
+	scoped.headers.Set("X-Disable-Provenance", "true")
+	return &scoped
+}
+
+// AlertRules lists all alert rules
+func (c *Client) AlertRules() ([]AlertRule, error) {
+	return c.AlertRulesContext(context.Background())
+}
+
+// AlertRulesContext is like AlertRules but takes a context for cancellation and deadlines.
+func (c *Client) AlertRulesContext(ctx context.Context) ([]AlertRule, error) {
+	rules := make([]AlertRule, 0)
+	err := c.request(ctx, "GET", "/api/v1/provisioning/alert-rules", nil, nil, &rules)
+	return rules, err
+}
+
+// AlertRule returns the alert rule with the given UID
+func (c *Client) AlertRule(uid string) (*AlertRule, error) {
+	return c.AlertRuleContext(context.Background(), uid)
+}
+
+// AlertRuleContext is like AlertRule but takes a context for cancellation and deadlines.
+func (c *Client) AlertRuleContext(ctx context.Context, uid string) (*AlertRule, error) {
+	result := &AlertRule{}
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/v1/provisioning/alert-rules/%s", uid), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// NewAlertRule creates an alert rule and returns it as stored by Grafana
+func (c *Client) NewAlertRule(rule *AlertRule) (*AlertRule, error) {
+	return c.NewAlertRuleContext(context.Background(), rule)
+}
+
+// NewAlertRuleContext is like NewAlertRule but takes a context for cancellation and deadlines.
+func (c *Client) NewAlertRuleContext(ctx context.Context, rule *AlertRule) (*AlertRule, error) {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+	result := &AlertRule{}
+	err = c.request(ctx, "POST", "/api/v1/provisioning/alert-rules", nil, bytes.NewBuffer(data), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// UpdateAlertRule replaces the alert rule with the UID of rule
+func (c *Client) UpdateAlertRule(rule *AlertRule) error {
+	return c.UpdateAlertRuleContext(context.Background(), rule)
+}
+
+// UpdateAlertRuleContext is like UpdateAlertRule but takes a context for cancellation and deadlines.
+func (c *Client) UpdateAlertRuleContext(ctx context.Context, rule *AlertRule) error {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PUT", fmt.Sprintf("/api/v1/provisioning/alert-rules/%s", rule.UID), nil, bytes.NewBuffer(data), nil)
+}
+
+// DeleteAlertRule deletes the alert rule with the given UID
+func (c *Client) DeleteAlertRule(uid string) error {
+	return c.DeleteAlertRuleContext(context.Background(), uid)
+}
+
+// DeleteAlertRuleContext is like DeleteAlertRule but takes a context for cancellation and deadlines.
+func (c *Client) DeleteAlertRuleContext(ctx context.Context, uid string) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/v1/provisioning/alert-rules/%s", uid), nil, nil, nil)
+}
+
+// AlertRuleGroup returns the rule group of a folder
+func (c *Client) AlertRuleGroup(folderUID, group string) (*AlertRuleGroup, error) {
+	return c.AlertRuleGroupContext(context.Background(), folderUID, group)
+}
+
+// AlertRuleGroupContext is like AlertRuleGroup but takes a context for cancellation and deadlines.
+func (c *Client) AlertRuleGroupContext(ctx context.Context, folderUID, group string) (*AlertRuleGroup, error) {
+	result := &AlertRuleGroup{}
+	err := c.request(ctx, "GET", alertRuleGroupPath(folderUID, group), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// SetAlertRuleGroup creates or replaces a rule group including its rules
+func (c *Client) SetAlertRuleGroup(group AlertRuleGroup) error {
+	return c.SetAlertRuleGroupContext(context.Background(), group)
+}
+
+// SetAlertRuleGroupContext is like SetAlertRuleGroup but takes a context for cancellation and deadlines.
+func (c *Client) SetAlertRuleGroupContext(ctx context.Context, group AlertRuleGroup) error {
+	data, err := json.Marshal(group)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PUT", alertRuleGroupPath(group.FolderUID, group.Title), nil, bytes.NewBuffer(data), nil)
+}
+
+func alertRuleGroupPath(folderUID, group string) string {
+	return fmt.Sprintf("/api/v1/provisioning/folder/%s/rule-groups/%s", folderUID, group)
+}
+
+// ExportAlertRules exports all alert rules in the provisioning file format
+func (c *Client) ExportAlertRules(format string) (string, error) {
+	return c.ExportAlertRulesContext(context.Background(), format)
+}
+
+// ExportAlertRulesContext is like ExportAlertRules but takes a context for cancellation and deadlines.
+func (c *Client) ExportAlertRulesContext(ctx context.Context, format string) (string, error) {
+	return c.exportAlerting(ctx, "/api/v1/provisioning/alert-rules/export", format)
+}
+
+// ExportAlertRule exports a single alert rule in the provisioning file format
+func (c *Client) ExportAlertRule(uid, format string) (string, error) {
+	return c.ExportAlertRuleContext(context.Background(), uid, format)
+}
+
+// ExportAlertRuleContext is like ExportAlertRule but takes a context for cancellation and deadlines.
+func (c *Client) ExportAlertRuleContext(ctx context.Context, uid, format string) (string, error) {
+	return c.exportAlerting(ctx, fmt.Sprintf("/api/v1/provisioning/alert-rules/%s/export", uid), format)
+}
+
+// ExportAlertRuleGroup exports a rule group in the provisioning file format
+func (c *Client) ExportAlertRuleGroup(folderUID, group, format string) (string, error) {
+	return c.ExportAlertRuleGroupContext(context.Background(), folderUID, group, format)
+}
+
+// ExportAlertRuleGroupContext is like ExportAlertRuleGroup but takes a context for cancellation and deadlines.
+func (c *Client) ExportAlertRuleGroupContext(ctx context.Context, folderUID, group, format string) (string, error) {
+	return c.exportAlerting(ctx, alertRuleGroupPath(folderUID, group)+"/export", format)
+}
+
+func (c *Client) exportAlerting(ctx context.Context, path, format string) (string, error) {
+	params := url.Values{}
+	if format != "" {
+		params.Add("format", format)
+	}
+	data, err := c.requestRaw(ctx, "GET", path, params, nil)
+	return string(data), err
+}
@@ -0,0 +1,223 @@
+package gapi
+
+import (
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getAlertRuleJSON = `
+{
+  "id": 1,
+  "uid": "eF2fPykVk",
+  "orgID": 1,
+  "folderUID": "project_x",
+  "ruleGroup": "eval_group_1",
+  "title": "High CPU",
+  "condition": "B",
+  "data": [
+    {
+      "refId": "A",
+      "queryType": "",
+      "relativeTimeRange": {"from": 600, "to": 0},
+      "datasourceUid": "PD8C576611E62080A",
+      "model": {"expr": "up", "refId": "A"}
+    },
+    {
+      "refId": "B",
+      "queryType": "",
+      "relativeTimeRange": {"from": 0, "to": 0},
+      "datasourceUid": "__expr__",
+      "model": {
+        "refId": "B",
+        "type": "threshold",
+        "expression": "A",
+        "conditions": [{"evaluator": {"type": "gt", "params": [80]}}]
+      }
+    }
+  ],
+  "updated": "2022-08-24T11:54:14+02:00",
+  "noDataState": "NoData",
+  "execErrState": "Alerting",
+  "for": "5m",
+  "labels": {"team": "infra"},
+  "isPaused": false,
+  "provenance": "api"
+}
+`
+	getAlertRulesJSON = `[` + getAlertRuleJSON + `]`
+
+	getAlertRuleGroupJSON = `
+{
+  "title": "eval_group_1",
+  "folderUid": "project_x",
+  "interval": 60,
+  "rules": [` + getAlertRuleJSON + `]
+}
+`
+	exportAlertRulesYAML = `apiVersion: 1
+groups:
+  - orgId: 1
+    name: eval_group_1
+    folder: project_x
+    interval: 1m
+`
+)
+
+func TestAlertRules(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getAlertRulesJSON)
+	defer server.Close()
+
+	rules, err := client.AlertRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/v1/provisioning/alert-rules")
+
+	t.Log(pretty.PrettyFormat(rules))
+
+	if len(rules) != 1 || rules[0].UID != "eF2fPykVk" || rules[0].For != "5m" || len(rules[0].Data) != 2 {
+		t.Error("Not correctly parsing returned alert rules.")
+	}
+}
+
+func TestAlertRule(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getAlertRuleJSON)
+	defer server.Close()
+
+	rule, err := client.AlertRule("eF2fPykVk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/v1/provisioning/alert-rules/eF2fPykVk")
+
+	if rule.Title != "High CPU" || rule.Labels["team"] != "infra" || rule.Updated == nil || rule.Updated.Minute() != 54 {
+		t.Error("Not correctly parsing returned alert rule.")
+	}
+
+	model := ExpressionModel{}
+	if err := rule.Data[1].DecodeModel(&model); err != nil {
+		t.Fatal(err)
+	}
+	if model.Type != "threshold" || len(model.Conditions) != 1 || model.Conditions[0].Evaluator.Params[0] != 80 {
+		t.Error("Not correctly decoding the expression model of an alert rule query.")
+	}
+}
+
+func TestNewAlertRule(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 201, getAlertRuleJSON)
+	defer server.Close()
+
+	rule := &AlertRule{
+		FolderUID: "project_x",
+		RuleGroup: "eval_group_1",
+		Title:     "High CPU",
+		Condition: "B",
+		Data: []*AlertRuleQuery{
+			NewExpressionQuery("B", ExpressionModel{
+				Type:       "threshold",
+				Expression: "A",
+				Conditions: []ExpressionCondition{{Evaluator: ConditionEvaluator{Type: "gt", Params: []float64{80}}}},
+			}),
+		},
+		For: "5m",
+	}
+	created, err := client.NewAlertRule(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("POST", "/api/v1/provisioning/alert-rules")
+
+	if created.UID != "eF2fPykVk" || created.Provenance != "api" {
+		t.Error("alert rule creation response should return the created alert rule")
+	}
+}
+
+func TestUpdateAlertRule(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getAlertRuleJSON)
+	defer server.Close()
+
+	err := client.UpdateAlertRule(&AlertRule{UID: "eF2fPykVk", Title: "High CPU"})
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/v1/provisioning/alert-rules/eF2fPykVk")
+}
+
+func TestDeleteAlertRule(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 204, "")
+	defer server.Close()
+
+	err := client.DeleteAlertRule("eF2fPykVk")
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/v1/provisioning/alert-rules/eF2fPykVk")
+}
+
+func TestAlertRuleGroup(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getAlertRuleGroupJSON)
+	defer server.Close()
+
+	group, err := client.AlertRuleGroup("project_x", "eval_group_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/v1/provisioning/folder/project_x/rule-groups/eval_group_1")
+
+	if group.Title != "eval_group_1" || group.Interval != 60 || len(group.Rules) != 1 {
+		t.Error("Not correctly parsing returned alert rule group.")
+	}
+}
+
+func TestSetAlertRuleGroup(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getAlertRuleGroupJSON)
+	defer server.Close()
+
+	err := client.SetAlertRuleGroup(AlertRuleGroup{Title: "eval_group_1", FolderUID: "project_x", Interval: 60})
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/v1/provisioning/folder/project_x/rule-groups/eval_group_1")
+}
+
+func TestExportAlertRules(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, exportAlertRulesYAML)
+	defer server.Close()
+
+	export, err := client.ExportAlertRules(AlertingExportFormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/v1/provisioning/alert-rules/export")
+
+	if export != exportAlertRulesYAML {
+		t.Errorf("Not correctly returning the export: %s", export)
+	}
+}
+
+func TestWithProvenanceDisabled(t *testing.T) {
+	server, _, rec := gapiRecorderTestTools(t, 200, getAlertRuleJSON)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithHeader("X-Custom", "foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.WithProvenanceDisabled().NewAlertRule(&AlertRule{Title: "High CPU"}); err != nil {
+		t.Fatal(err)
+	}
+	h := rec.last().Header
+	if h.Get("X-Disable-Provenance") != "true" || h.Get("X-Custom") != "foo" {
+		t.Errorf("expected the provenance header next to the custom headers, got %v", h)
+	}
+
+	if _, err := client.AlertRule("eF2fPykVk"); err != nil {
+		t.Fatal(err)
+	}
+	if h := rec.last().Header; h.Get("X-Disable-Provenance") != "" {
+		t.Error("expected the original client not to send the provenance header")
+	}
+}
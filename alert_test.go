@@ -0,0 +1,116 @@
+package gapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getAlertsJSON = `
+[
+  {
+    "id": 1,
+    "dashboardId": 1,
+    "dashboardUid": "ABcdEFghij",
+    "dashboardSlug": "sensors",
+    "panelId": 1,
+    "name": "fire place sensor",
+    "state": "alerting",
+    "newStateDate": "2018-05-14T05:55:20+02:00",
+    "evalDate": "0001-01-01T00:00:00Z",
+    "evalData": {
+      "evalMatches": [
+        {"metric": "movement", "tags": {"name": "fireplace_chimney"}, "value": 100},
+        {"metric": "temperature", "tags": null, "value": null}
+      ]
+    },
+    "executionError": "",
+    "url": "http://grafana.com/dashboard/db/sensors"
+  }
+]
+`
+	getAlertJSON = `
+{
+  "id": 2,
+  "dashboardId": 1,
+  "dashboardUid": "ABcdEFghij",
+  "dashboardSlug": "sensors",
+  "panelId": 2,
+  "name": "door sensor",
+  "state": "no_data",
+  "newStateDate": "2018-05-14T05:55:20+02:00",
+  "evalDate": "2018-05-14T06:00:20+02:00",
+  "evalData": {"noData": true},
+  "executionError": "",
+  "url": "http://grafana.com/dashboard/db/sensors"
+}
+`
+	pauseAlertJSON = `{"alertId":1,"state":"Paused","message":"alert paused"}`
+)
+
+func TestAlerts(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getAlertsJSON)
+	defer server.Close()
+
+	alerts, err := client.Alerts(AlertQuery{
+		DashboardIDs: []int64{1},
+		States:       []string{AlertStateAlerting, AlertStatePending},
+		FolderIDs:    []int64{3},
+		Limit:        10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(pretty.PrettyFormat(alerts))
+
+	expected := map[string][]string{
+		"dashboardId": {"1"},
+		"state":       {"alerting", "pending"},
+		"folderId":    {"3"},
+		"limit":       {"10"},
+	}
+	if q := rec.expect("GET", "/api/alerts").Query; !reflect.DeepEqual(map[string][]string(q), expected) {
+		t.Errorf("unexpected alerts query %v", q)
+	}
+
+	if len(alerts) != 1 || alerts[0].State != AlertStateAlerting || alerts[0].NewStateDate.Minute() != 55 || !alerts[0].EvalDate.IsZero() {
+		t.Fatal("Not correctly parsing returned alerts.")
+	}
+	matches := alerts[0].EvalData.EvalMatches
+	if len(matches) != 2 || *matches[0].Value != 100 || matches[0].Tags["name"] != "fireplace_chimney" || matches[1].Value != nil {
+		t.Error("Not correctly parsing the eval data of alerts.")
+	}
+}
+
+func TestAlert(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getAlertJSON)
+	defer server.Close()
+
+	alert, err := client.Alert(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/alerts/2")
+
+	if alert.Name != "door sensor" || alert.State != AlertStateNoData || !alert.EvalData.NoData {
+		t.Error("Not correctly parsing returned alert.")
+	}
+}
+
+func TestPauseAlert(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, pauseAlertJSON)
+	defer server.Close()
+
+	result, err := client.PauseAlert(1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("POST", "/api/alerts/1/pause")
+
+	if result.AlertID != 1 || result.State != "Paused" {
+		t.Error("Not correctly parsing returned pause message.")
+	}
+}
@@ -2,111 +2,311 @@ package gapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
 )
 
+// Types of the legacy alert notifiers with typed settings
+const (
+	NotifierTypeSlack     = "slack"
+	NotifierTypeEmail     = "email"
+	NotifierTypePagerDuty = "pagerduty"
+	NotifierTypeWebhook   = "webhook"
+	NotifierTypeOpsgenie  = "opsgenie"
+)
+
+// AlertNotification is a legacy alert notification channel. Settings is
+// decoded into the settings struct of Type, e.g. *SlackSettings, and into a
+// map[string]interface{} for notifiers without typed settings. The typed
+// settings keep keys without a field in Extra, so a fetched notification can
+// be passed to UpdateAlertNotification without losing settings.
 type AlertNotification struct {
-	Id        int64       `json:"id,omitempty"`
-	Name      string      `json:"name"`
-	Type      string      `json:"type"`
-	IsDefault bool        `json:"isDefault"`
-	Settings  interface{} `json:"settings"`
+	Id                    int64             `json:"id,omitempty"`
+	Uid                   string            `json:"uid,omitempty"`
+	Name                  string            `json:"name"`
+	Type                  string            `json:"type"`
+	IsDefault             bool              `json:"isDefault"`
+	SendReminder          bool              `json:"sendReminder"`
+	Frequency             string            `json:"frequency,omitempty"`
+	DisableResolveMessage bool              `json:"disableResolveMessage"`
+	Settings              interface{}       `json:"settings"`
+	SecureSettings        map[string]string `json:"secureSettings,omitempty"`
 }
 
-func (c *Client) AlertNotification(id int64) (*AlertNotification, error) {
-	path := fmt.Sprintf("/api/alert-notifications/%d", id)
-	req, err := c.newRequest("GET", path, nil, nil)
+// SlackSettings are the settings of the slack notifier
+type SlackSettings struct {
+	URL            string `json:"url,omitempty"`
+	Recipient      string `json:"recipient,omitempty"`
+	Username       string `json:"username,omitempty"`
+	IconEmoji      string `json:"icon_emoji,omitempty"`
+	IconURL        string `json:"icon_url,omitempty"`
+	MentionUsers   string `json:"mentionUsers,omitempty"`
+	MentionGroups  string `json:"mentionGroups,omitempty"`
+	MentionChannel string `json:"mentionChannel,omitempty"`
+	Token          string `json:"token,omitempty"`
+	UploadImage    bool   `json:"uploadImage"`
+
+	Extra map[string]interface{} `json:"-"`
+}
+
+// EmailSettings are the settings of the email notifier, Addresses are separated by ;
+type EmailSettings struct {
+	Addresses   string `json:"addresses"`
+	SingleEmail bool   `json:"singleEmail"`
+	UploadImage bool   `json:"uploadImage"`
+
+	Extra map[string]interface{} `json:"-"`
+}
+
+// PagerDutySettings are the settings of the pagerduty notifier
+type PagerDutySettings struct {
+	IntegrationKey   string `json:"integrationKey,omitempty"`
+	Severity         string `json:"severity,omitempty"`
+	AutoResolve      bool   `json:"autoResolve"`
+	MessageInDetails bool   `json:"messageInDetails"`
+
+	Extra map[string]interface{} `json:"-"`
+}
+
+// WebhookSettings are the settings of the webhook notifier
+type WebhookSettings struct {
+	URL        string `json:"url"`
+	HTTPMethod string `json:"httpMethod,omitempty"`
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+
+	Extra map[string]interface{} `json:"-"`
+}
+
+// OpsgenieSettings are the settings of the opsgenie notifier
+type OpsgenieSettings struct {
+	APIKey           string `json:"apiKey,omitempty"`
+	APIURL           string `json:"apiUrl,omitempty"`
+	AutoClose        bool   `json:"autoClose"`
+	OverridePriority bool   `json:"overridePriority"`
+	SendTagsAs       string `json:"sendTagsAs,omitempty"`
+
+	Extra map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON encodes the fields together with the Extra keys
+func (s SlackSettings) MarshalJSON() ([]byte, error) {
+	type slackSettings SlackSettings
+	return marshalWithExtra(slackSettings(s), s.Extra)
+}
+
+// UnmarshalJSON decodes the known keys into the fields and all others into Extra
+func (s *SlackSettings) UnmarshalJSON(data []byte) error {
+	type slackSettings SlackSettings
+	known := slackSettings{}
+	extra, err := unmarshalWithExtra(data, &known)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	*s = SlackSettings(known)
+	s.Extra = extra
+	return nil
+}
 
-	resp, err := c.Do(req)
+// MarshalJSON encodes the fields together with the Extra keys
+func (s EmailSettings) MarshalJSON() ([]byte, error) {
+	type emailSettings EmailSettings
+	return marshalWithExtra(emailSettings(s), s.Extra)
+}
+
+// UnmarshalJSON decodes the known keys into the fields and all others into Extra
+func (s *EmailSettings) UnmarshalJSON(data []byte) error {
+	type emailSettings EmailSettings
+	known := emailSettings{}
+	extra, err := unmarshalWithExtra(data, &known)
 	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
+		return err
 	}
+	*s = EmailSettings(known)
+	s.Extra = extra
+	return nil
+}
 
-	data, err := ioutil.ReadAll(resp.Body)
+// MarshalJSON encodes the fields together with the Extra keys
+func (s PagerDutySettings) MarshalJSON() ([]byte, error) {
+	type pagerDutySettings PagerDutySettings
+	return marshalWithExtra(pagerDutySettings(s), s.Extra)
+}
+
+// UnmarshalJSON decodes the known keys into the fields and all others into Extra
+func (s *PagerDutySettings) UnmarshalJSON(data []byte) error {
+	type pagerDutySettings PagerDutySettings
+	known := pagerDutySettings{}
+	extra, err := unmarshalWithExtra(data, &known)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	*s = PagerDutySettings(known)
+	s.Extra = extra
+	return nil
+}
 
-	result := &AlertNotification{}
-	err = json.Unmarshal(data, &result)
-	return result, err
+// MarshalJSON encodes the fields together with the Extra keys
+func (s WebhookSettings) MarshalJSON() ([]byte, error) {
+	type webhookSettings WebhookSettings
+	return marshalWithExtra(webhookSettings(s), s.Extra)
 }
 
-func (c *Client) NewAlertNotification(a *AlertNotification) (int64, error) {
-	data, err := json.Marshal(a)
+// UnmarshalJSON decodes the known keys into the fields and all others into Extra
+func (s *WebhookSettings) UnmarshalJSON(data []byte) error {
+	type webhookSettings WebhookSettings
+	known := webhookSettings{}
+	extra, err := unmarshalWithExtra(data, &known)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	req, err := c.newRequest("POST", "/api/alert-notifications", nil, bytes.NewBuffer(data))
+	*s = WebhookSettings(known)
+	s.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes the fields together with the Extra keys
+func (s OpsgenieSettings) MarshalJSON() ([]byte, error) {
+	type opsgenieSettings OpsgenieSettings
+	return marshalWithExtra(opsgenieSettings(s), s.Extra)
+}
+
+// UnmarshalJSON decodes the known keys into the fields and all others into Extra
+func (s *OpsgenieSettings) UnmarshalJSON(data []byte) error {
+	type opsgenieSettings OpsgenieSettings
+	known := opsgenieSettings{}
+	extra, err := unmarshalWithExtra(data, &known)
 	if err != nil {
-		return 0, err
+		return err
+	}
+	*s = OpsgenieSettings(known)
+	s.Extra = extra
+	return nil
+}
+
+// UnmarshalJSON decodes the settings into the settings struct of the notifier type
+func (a *AlertNotification) UnmarshalJSON(data []byte) error {
+	type alertNotification AlertNotification
+	raw := struct {
+		*alertNotification
+		Settings json.RawMessage `json:"settings"`
+	}{alertNotification: (*alertNotification)(a)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
 	}
 
-	resp, err := c.Do(req)
+	a.Settings = nil
+	if len(raw.Settings) == 0 || string(raw.Settings) == "null" {
+		return nil
+	}
+	var settings interface{}
+	switch a.Type {
+	case NotifierTypeSlack:
+		settings = &SlackSettings{}
+	case NotifierTypeEmail:
+		settings = &EmailSettings{}
+	case NotifierTypePagerDuty:
+		settings = &PagerDutySettings{}
+	case NotifierTypeWebhook:
+		settings = &WebhookSettings{}
+	case NotifierTypeOpsgenie:
+		settings = &OpsgenieSettings{}
+	default:
+		m := map[string]interface{}{}
+		if err := json.Unmarshal(raw.Settings, &m); err != nil {
+			return err
+		}
+		a.Settings = m
+		return nil
+	}
+	if err := json.Unmarshal(raw.Settings, settings); err != nil {
+		return fmt.Errorf("invalid %s notifier settings: %w", a.Type, err)
+	}
+	a.Settings = settings
+	return nil
+}
+
+// AlertNotifications lists all alert notification channels
+func (c *Client) AlertNotifications() ([]AlertNotification, error) {
+	return c.AlertNotificationsContext(context.Background())
+}
+
+// AlertNotificationsContext is like AlertNotifications but takes a context for cancellation and deadlines.
+func (c *Client) AlertNotificationsContext(ctx context.Context) ([]AlertNotification, error) {
+	notifications := make([]AlertNotification, 0)
+	err := c.request(ctx, "GET", "/api/alert-notifications", nil, nil, &notifications)
+	return notifications, err
+}
+
+// AlertNotificationByUID returns the alert notification channel with the given UID
+func (c *Client) AlertNotificationByUID(uid string) (*AlertNotification, error) {
+	return c.AlertNotificationByUIDContext(context.Background(), uid)
+}
+
+// AlertNotificationByUIDContext is like AlertNotificationByUID but takes a context for cancellation and deadlines.
+func (c *Client) AlertNotificationByUIDContext(ctx context.Context, uid string) (*AlertNotification, error) {
+	path := fmt.Sprintf("/api/alert-notifications/uid/%s", uid)
+	result := &AlertNotification{}
+	err := c.request(ctx, "GET", path, nil, nil, result)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	if resp.StatusCode != 200 {
-		return 0, errors.New(resp.Status)
+	return result, err
+}
+
+func (c *Client) AlertNotification(id int64) (*AlertNotification, error) {
+	return c.AlertNotificationContext(context.Background(), id)
+}
+
+// AlertNotificationContext is like AlertNotification but takes a context for cancellation and deadlines.
+func (c *Client) AlertNotificationContext(ctx context.Context, id int64) (*AlertNotification, error) {
+	path := fmt.Sprintf("/api/alert-notifications/%d", id)
+	result := &AlertNotification{}
+	err := c.request(ctx, "GET", path, nil, nil, result)
+	if err != nil {
+		return nil, err
 	}
+	return result, err
+}
+
+func (c *Client) NewAlertNotification(a *AlertNotification) (int64, error) {
+	return c.NewAlertNotificationContext(context.Background(), a)
+}
 
-	data, err = ioutil.ReadAll(resp.Body)
+// NewAlertNotificationContext is like NewAlertNotification but takes a context for cancellation and deadlines.
+func (c *Client) NewAlertNotificationContext(ctx context.Context, a *AlertNotification) (int64, error) {
+	data, err := json.Marshal(a)
 	if err != nil {
 		return 0, err
 	}
-
 	result := struct {
 		Id int64 `json:"id"`
 	}{}
-	err = json.Unmarshal(data, &result)
+	err = c.request(ctx, "POST", "/api/alert-notifications", nil, bytes.NewBuffer(data), &result)
 	return result.Id, err
 }
 
 func (c *Client) UpdateAlertNotification(a *AlertNotification) error {
+	return c.UpdateAlertNotificationContext(context.Background(), a)
+}
+
+// UpdateAlertNotificationContext is like UpdateAlertNotification but takes a context for cancellation and deadlines.
+func (c *Client) UpdateAlertNotificationContext(ctx context.Context, a *AlertNotification) error {
 	path := fmt.Sprintf("/api/alert-notifications/%d", a.Id)
 	data, err := json.Marshal(a)
 	if err != nil {
 		return err
 	}
-	req, err := c.newRequest("PUT", path, nil, bytes.NewBuffer(data))
-	if err != nil {
-		return err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
-	}
-
-	return nil
+	return c.request(ctx, "PUT", path, nil, bytes.NewBuffer(data), nil)
 }
 
 func (c *Client) DeleteAlertNotification(id int64) error {
-	path := fmt.Sprintf("/api/alert-notifications/%d", id)
-	req, err := c.newRequest("DELETE", path, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
-	}
+	return c.DeleteAlertNotificationContext(context.Background(), id)
+}
 
-	return nil
+// DeleteAlertNotificationContext is like DeleteAlertNotification but takes a context for cancellation and deadlines.
+func (c *Client) DeleteAlertNotificationContext(ctx context.Context, id int64) error {
+	path := fmt.Sprintf("/api/alert-notifications/%d", id)
+	return c.request(ctx, "DELETE", path, nil, nil, nil)
 }
@@ -0,0 +1,162 @@
+package gapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getAlertNotificationsJSON = `
+[
+  {
+    "id": 1,
+    "uid": "team-a-email-notifier",
+    "name": "Team A",
+    "type": "email",
+    "isDefault": false,
+    "sendReminder": false,
+    "disableResolveMessage": false,
+    "settings": {
+      "addresses": "dev@grafana.com"
+    },
+    "created": "2018-04-23T14:44:09+02:00",
+    "updated": "2018-08-20T15:47:49+02:00"
+  },
+  {
+    "id": 2,
+    "uid": "infra-slack",
+    "name": "Infra",
+    "type": "slack",
+    "isDefault": true,
+    "sendReminder": true,
+    "frequency": "15m",
+    "disableResolveMessage": true,
+    "settings": {
+      "recipient": "#infra",
+      "icon_emoji": ":fire:",
+      "uploadImage": true
+    }
+  },
+  {
+    "id": 3,
+    "uid": "line",
+    "name": "Line",
+    "type": "LINE",
+    "settings": {
+      "token": "secret"
+    }
+  }
+]
+`
+	getAlertNotificationJSON = `
+{
+  "id": 4,
+  "uid": "ops-pager",
+  "name": "Ops",
+  "type": "pagerduty",
+  "settings": {
+    "severity": "critical",
+    "autoResolve": true,
+    "class": "ping failure",
+    "component": "webserver"
+  }
+}
+`
+)
+
+func TestAlertNotifications(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getAlertNotificationsJSON)
+	defer server.Close()
+
+	notifications, err := client.AlertNotifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/alert-notifications")
+
+	t.Log(pretty.PrettyFormat(notifications))
+
+	if len(notifications) != 3 {
+		t.Fatal("Not correctly parsing returned alert notifications.")
+	}
+	email, ok := notifications[0].Settings.(*EmailSettings)
+	if !ok || email.Addresses != "dev@grafana.com" {
+		t.Error("Not correctly parsing the email notifier settings.")
+	}
+	slack, ok := notifications[1].Settings.(*SlackSettings)
+	if !ok || slack.Recipient != "#infra" || slack.IconEmoji != ":fire:" || !slack.UploadImage {
+		t.Error("Not correctly parsing the slack notifier settings.")
+	}
+	if notifications[1].Frequency != "15m" || !notifications[1].SendReminder || !notifications[1].DisableResolveMessage {
+		t.Error("Not correctly parsing the reminder settings of alert notifications.")
+	}
+	if settings, ok := notifications[2].Settings.(map[string]interface{}); !ok || settings["token"] != "secret" {
+		t.Error("expected the settings of unknown notifiers to be decoded into a map")
+	}
+}
+
+func TestAlertNotificationByUID(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getAlertNotificationJSON)
+	defer server.Close()
+
+	notification, err := client.AlertNotificationByUID("ops-pager")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/alert-notifications/uid/ops-pager")
+
+	settings, ok := notification.Settings.(*PagerDutySettings)
+	if notification.Uid != "ops-pager" || !ok || settings.Severity != "critical" || !settings.AutoResolve {
+		t.Error("Not correctly parsing returned alert notification.")
+	}
+	if settings.Extra["class"] != "ping failure" || settings.Extra["component"] != "webserver" {
+		t.Errorf("expected settings without a field to be kept, got %v", settings.Extra)
+	}
+
+	data, err := json.Marshal(notification)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := struct {
+		Settings map[string]interface{} `json:"settings"`
+	}{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Settings["class"] != "ping failure" || decoded.Settings["severity"] != "critical" {
+		t.Errorf("expected all settings to be written back, got %s", data)
+	}
+}
+
+func TestAlertNotificationSettingsRoundTrip(t *testing.T) {
+	a := AlertNotification{
+		Name:           "hook",
+		Type:           NotifierTypeWebhook,
+		Settings:       WebhookSettings{URL: "http://example.com", HTTPMethod: "POST"},
+		SecureSettings: map[string]string{"password": "secret"},
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := AlertNotification{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	settings, ok := decoded.Settings.(*WebhookSettings)
+	if !ok || !reflect.DeepEqual(*settings, a.Settings) || decoded.SecureSettings["password"] != "secret" {
+		t.Errorf("expected the settings to survive a round trip, got %s", data)
+	}
+}
+
+func TestAlertNotificationInvalidSettings(t *testing.T) {
+	decoded := AlertNotification{}
+	err := json.Unmarshal([]byte(`{"type": "slack", "settings": {"uploadImage": "yes"}}`), &decoded)
+	if err == nil {
+		t.Error("expected an error for settings not matching the notifier type")
+	}
+}
@@ -2,10 +2,9 @@ package gapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
 )
 
 // Annotation represents a Grafana API Annotation
@@ -38,176 +37,101 @@ type GraphiteAnnotation struct {
 
 // Annotations fetches the annotations queried with the params it's passed
 func (c *Client) Annotations(params map[string]string) ([]Annotation, error) {
-	pathAndQuery := buildPathAndQuery("/api/annotations", params)
-	req, err := c.newRequest("GET", pathAndQuery, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
-	}
+	return c.AnnotationsContext(context.Background(), params)
+}
 
-	data, err := ioutil.ReadAll(resp.Body)
+// AnnotationsContext is like Annotations but takes a context for cancellation and deadlines.
+func (c *Client) AnnotationsContext(ctx context.Context, params map[string]string) ([]Annotation, error) {
+	pathAndQuery := buildPathAndQuery("/api/annotations", params)
+	result := []Annotation{}
+	err := c.request(ctx, "GET", pathAndQuery, nil, nil, &result)
 	if err != nil {
 		return nil, err
 	}
-
-	result := []Annotation{}
-	err = json.Unmarshal(data, &result)
 	return result, err
 }
 
 // NewAnnotation creates a new annotation with the Annotation it is passed
 func (c *Client) NewAnnotation(a *Annotation) (int64, error) {
-	data, err := json.Marshal(a)
-	if err != nil {
-		return 0, err
-	}
-	req, err := c.newRequest("POST", "/api/annotations", nil, bytes.NewBuffer(data))
-	if err != nil {
-		return 0, err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return 0, err
-	}
-	if resp.StatusCode != 200 {
-		return 0, errors.New(resp.Status)
-	}
+	return c.NewAnnotationContext(context.Background(), a)
+}
 
-	data, err = ioutil.ReadAll(resp.Body)
+// NewAnnotationContext is like NewAnnotation but takes a context for cancellation and deadlines.
+func (c *Client) NewAnnotationContext(ctx context.Context, a *Annotation) (int64, error) {
+	data, err := json.Marshal(a)
 	if err != nil {
 		return 0, err
 	}
-
 	result := struct {
 		ID int64 `json:"id"`
 	}{}
-	err = json.Unmarshal(data, &result)
+	err = c.request(ctx, "POST", "/api/annotations", nil, bytes.NewBuffer(data), &result)
 	return result.ID, err
 }
 
 // NewGraphiteAnnotation creates a new annotation with the GraphiteAnnotation it is passed
 func (c *Client) NewGraphiteAnnotation(gfa *GraphiteAnnotation) (int64, error) {
-	data, err := json.Marshal(gfa)
-	if err != nil {
-		return 0, err
-	}
-	req, err := c.newRequest("POST", "/api/annotations/graphite", nil, bytes.NewBuffer(data))
-	if err != nil {
-		return 0, err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return 0, err
-	}
-	if resp.StatusCode != 200 {
-		return 0, errors.New(resp.Status)
-	}
+	return c.NewGraphiteAnnotationContext(context.Background(), gfa)
+}
 
-	data, err = ioutil.ReadAll(resp.Body)
+// NewGraphiteAnnotationContext is like NewGraphiteAnnotation but takes a context for cancellation and deadlines.
+func (c *Client) NewGraphiteAnnotationContext(ctx context.Context, gfa *GraphiteAnnotation) (int64, error) {
+	data, err := json.Marshal(gfa)
 	if err != nil {
 		return 0, err
 	}
-
 	result := struct {
 		ID int64 `json:"id"`
 	}{}
-	err = json.Unmarshal(data, &result)
+	err = c.request(ctx, "POST", "/api/annotations/graphite", nil, bytes.NewBuffer(data), &result)
 	return result.ID, err
 }
 
 // UpdateAnnotation updates an existing annotation with the Annotation it is passed
 func (c *Client) UpdateAnnotation(a *Annotation) (int64, error) {
+	return c.UpdateAnnotationContext(context.Background(), a)
+}
+
+// UpdateAnnotationContext is like UpdateAnnotation but takes a context for cancellation and deadlines.
+func (c *Client) UpdateAnnotationContext(ctx context.Context, a *Annotation) (int64, error) {
 	path := fmt.Sprintf("/api/annotations/%d", a.ID)
 	data, err := json.Marshal(a)
 	if err != nil {
 		return 0, err
 	}
-	req, err := c.newRequest("PUT", path, nil, bytes.NewBuffer(data))
-	if err != nil {
-		return 0, err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return 0, err
-	}
-	if resp.StatusCode != 200 {
-		return 0, errors.New(resp.Status)
-	}
-
-	data, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return 0, err
-	}
-
 	result := struct {
 		ID int64 `json:"id"`
 	}{}
-	err = json.Unmarshal(data, &result)
+	err = c.request(ctx, "PUT", path, nil, bytes.NewBuffer(data), &result)
 	return result.ID, err
 }
 
 // DeleteAnnotation deletes the annotation of the ID it is passed
 func (c *Client) DeleteAnnotation(id int64) (string, error) {
-	path := fmt.Sprintf("/api/annotations/%d", id)
-	req, err := c.newRequest("DELETE", path, nil, bytes.NewBuffer(nil))
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return "", err
-	}
-	if resp.StatusCode != 200 {
-		return "", errors.New(resp.Status)
-	}
-
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
+	return c.DeleteAnnotationContext(context.Background(), id)
+}
 
+// DeleteAnnotationContext is like DeleteAnnotation but takes a context for cancellation and deadlines.
+func (c *Client) DeleteAnnotationContext(ctx context.Context, id int64) (string, error) {
+	path := fmt.Sprintf("/api/annotations/%d", id)
 	result := struct {
 		Message string `json:"message"`
 	}{}
-	err = json.Unmarshal(data, &result)
+	err := c.request(ctx, "DELETE", path, nil, bytes.NewBuffer(nil), &result)
 	return result.Message, err
 }
 
 // DeleteAnnotationByRegionID deletes the annotation corresponding to the region ID it is passed
 func (c *Client) DeleteAnnotationByRegionID(id int64) (string, error) {
-	path := fmt.Sprintf("/api/annotations/region/%d", id)
-	req, err := c.newRequest("DELETE", path, nil, bytes.NewBuffer(nil))
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return "", err
-	}
-	if resp.StatusCode != 200 {
-		return "", errors.New(resp.Status)
-	}
-
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
+	return c.DeleteAnnotationByRegionIDContext(context.Background(), id)
+}
 
+// DeleteAnnotationByRegionIDContext is like DeleteAnnotationByRegionID but takes a context for cancellation and deadlines.
+func (c *Client) DeleteAnnotationByRegionIDContext(ctx context.Context, id int64) (string, error) {
+	path := fmt.Sprintf("/api/annotations/region/%d", id)
 	result := struct {
 		Message string `json:"message"`
 	}{}
-	err = json.Unmarshal(data, &result)
+	err := c.request(ctx, "DELETE", path, nil, bytes.NewBuffer(nil), &result)
 	return result.Message, err
 }
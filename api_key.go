@@ -0,0 +1,80 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// APIKey represents an API key of the current org, the secret is only
+// returned once by NewAPIKey
+type APIKey struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+}
+
+// CreateAPIKeyRequest is the payload to create an API key, the key never
+// expires if SecondsToLive is zero
+type CreateAPIKeyRequest struct {
+	Name          string `json:"name"`
+	Role          string `json:"role"`
+	SecondsToLive int64  `json:"secondsToLive,omitempty"`
+}
+
+// CreateAPIKeyResponse is the created API key
+type CreateAPIKeyResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Key is the secret to authenticate with, Grafana does not return it again
+	Key string `json:"key"`
+}
+
+// APIKeys lists the API keys of the current org
+func (c *Client) APIKeys(includeExpired bool) ([]APIKey, error) {
+	return c.APIKeysContext(context.Background(), includeExpired)
+}
+
+// APIKeysContext is like APIKeys but takes a context for cancellation and deadlines.
+func (c *Client) APIKeysContext(ctx context.Context, includeExpired bool) ([]APIKey, error) {
+	keys := make([]APIKey, 0)
+	params := url.Values{}
+	if includeExpired {
+		params.Add("includeExpired", "true")
+	}
+	err := c.request(ctx, "GET", "/api/auth/keys", params, nil, &keys)
+	return keys, err
+}
+
+// NewAPIKey creates an API key in the current org
+func (c *Client) NewAPIKey(request CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return c.NewAPIKeyContext(context.Background(), request)
+}
+
+// NewAPIKeyContext is like NewAPIKey but takes a context for cancellation and deadlines.
+func (c *Client) NewAPIKeyContext(ctx context.Context, request CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	result := &CreateAPIKeyResponse{}
+	err = c.request(ctx, "POST", "/api/auth/keys", nil, bytes.NewBuffer(data), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// DeleteAPIKey deletes the API key with the given id
+func (c *Client) DeleteAPIKey(id int64) error {
+	return c.DeleteAPIKeyContext(context.Background(), id)
+}
+
+// DeleteAPIKeyContext is like DeleteAPIKey but takes a context for cancellation and deadlines.
+func (c *Client) DeleteAPIKeyContext(ctx context.Context, id int64) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/auth/keys/%d", id), nil, nil, nil)
+}
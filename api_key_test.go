@@ -0,0 +1,73 @@
+package gapi
+
+import (
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getAPIKeysJSON = `
+[
+  {
+    "id": 1,
+    "name": "TestAdmin",
+    "role": "Admin"
+  },
+  {
+    "id": 2,
+    "name": "TestViewer",
+    "role": "Viewer",
+    "expiration": "2019-06-26T10:52:03+03:00"
+  }
+]
+`
+	createdAPIKeyJSON = `{"id":1,"name":"mykey","key":"eyJrIjoiWHZiSWd3NzdCYUZnNUtibE9obUpESmE3bzJYNDRIc0UiLCJuIjoibXlrZXkiLCJpZCI6MX1="}`
+	deletedAPIKeyJSON = `{"message":"API key deleted"}`
+)
+
+func TestAPIKeys(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getAPIKeysJSON)
+	defer server.Close()
+
+	keys, err := client.APIKeys(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/auth/keys")
+
+	t.Log(pretty.PrettyFormat(keys))
+
+	if len(keys) != 2 || keys[0].Name != "TestAdmin" || keys[0].Expiration != nil {
+		t.Fatal("Not correctly parsing returned API keys.")
+	}
+	if keys[1].Expiration == nil || keys[1].Expiration.Year() != 2019 {
+		t.Error("Not correctly parsing the expiration of returned API keys.")
+	}
+}
+
+func TestNewAPIKey(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, createdAPIKeyJSON)
+	defer server.Close()
+
+	resp, err := client.NewAPIKey(CreateAPIKeyRequest{Name: "mykey", Role: "Admin", SecondsToLive: 3600})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("POST", "/api/auth/keys")
+
+	if resp.ID != 1 || resp.Key == "" {
+		t.Error("Not correctly parsing returned API key.")
+	}
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, deletedAPIKeyJSON)
+	defer server.Close()
+
+	err := client.DeleteAPIKey(1)
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/auth/keys/1")
+}
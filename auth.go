@@ -0,0 +1,39 @@
+package gapi
+
+import (
+	"net/http"
+)
+
+// AuthProvider adds the credentials to every request sent by a Client
+type AuthProvider interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthProviderFunc adapts a function to an AuthProvider
+type AuthProviderFunc func(req *http.Request) error
+
+// Authenticate calls f(req)
+func (f AuthProviderFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// BasicAuth authenticates with a Grafana user and password
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// Authenticate sets the basic auth header
+func (a BasicAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+// BearerToken authenticates with an API key or a service account token
+type BearerToken string
+
+// Authenticate sets the bearer token as authorization header
+func (t BearerToken) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+string(t))
+	return nil
+}
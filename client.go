@@ -2,63 +2,268 @@ package gapi
 
 import (
 	"bytes"
-	"fmt"
+	"context"
+	"encoding/json"
 	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Client struct {
-	key     string
-	baseURL url.URL
+	baseURL   url.URL
+	auth      AuthProvider
+	orgID     int64
+	headers   http.Header
+	userAgent string
+	config    ClientConfig
+	limiter   *limiter
 	*http.Client
 }
 
-//New creates a new grafana client
-//auth can be in user:pass format, or it can be an api key
-func New(auth, baseURL string) (*Client, error) {
+// ClientConfig holds the optional behaviour of a Client
+type ClientConfig struct {
+	// Retry enables retries of failed requests, nil disables them
+	Retry *RetryPolicy
+	// RateLimit is the number of requests per second the client sends at
+	// most, allowing bursts of RateBurst requests. Zero disables the limit
+	RateLimit float64
+	RateBurst int
+	// MaxInFlight limits the number of concurrent requests, zero disables the limit
+	MaxInFlight int
+}
+
+// NewClient creates a new grafana client for the given base URL,
+// see the With... functions for the available options
+func NewClient(baseURL string, opts ...Option) (*Client, error) {
 	u, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, err
 	}
-	key := ""
-	if strings.Contains(auth, ":") {
-		split := strings.Split(auth, ":")
-		u.User = url.UserPassword(split[0], split[1])
-	} else {
-		key = fmt.Sprintf("Bearer %s", auth)
+	o := &clientOptions{headers: http.Header{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	httpClient, err := o.buildHTTPClient()
+	if err != nil {
+		return nil, err
 	}
 	return &Client{
-		key,
-		*u,
-		&http.Client{},
+		baseURL:   *u,
+		auth:      o.auth,
+		orgID:     o.orgID,
+		headers:   o.headers,
+		userAgent: o.userAgent,
+		config:    o.config,
+		limiter:   newLimiter(o.config),
+		Client:    httpClient,
 	}, nil
 }
 
-func (c *Client) newRequest(method, requestPath string, query url.Values, body io.Reader) (*http.Request, error) {
-	url := c.baseURL
-	url.Path = path.Join(url.Path, requestPath)
-	url.RawQuery = query.Encode()
-	req, err := http.NewRequest(method, url.String(), body)
+// New creates a new grafana client
+// auth can be in user:pass format, or it can be an api key
+func New(auth, baseURL string) (*Client, error) {
+	return NewClient(baseURL, withAuthString(auth))
+}
+
+// NewWithConfig creates a new grafana client like New, using the given config
+func NewWithConfig(auth, baseURL string, config ClientConfig) (*Client, error) {
+	return NewClient(baseURL, withAuthString(auth), WithConfig(config))
+}
+
+// WithOrg returns a copy of the client that sends all requests in the
+// context of the given organization using the X-Grafana-Org-Id header.
+// The copy shares the http client, credentials and limits with c.
+func (c *Client) WithOrg(orgID int64) *Client {
+	scoped := *c
+	scoped.orgID = orgID
+	return &scoped
+}
+
+// WithToken returns a copy of the client that authenticates with the given
+// API key or service account token instead of the credentials of c
+func (c *Client) WithToken(token string) *Client {
+	scoped := *c
+	scoped.auth = BearerToken(token)
+	return &scoped
+}
+
+// OrgID returns the organization the client is scoped to, zero means the
+// current organization of the authenticated user
+func (c *Client) OrgID() int64 {
+	return c.orgID
+}
+
+// withAuthString keeps the behaviour of New, only the first colon separates
+// the user from the password
+func withAuthString(auth string) Option {
+	if split := strings.SplitN(auth, ":", 2); len(split) == 2 {
+		return WithBasicAuth(split[0], split[1])
+	}
+	return WithAPIKey(auth)
+}
+
+// newRequest builds a request carrying the client headers and header, keys
+// already set by the auth provider, org or client headers are skipped in
+// header. The Content-Type defaults to application/json unless header sets one.
+func (c *Client) newRequest(ctx context.Context, method, requestPath string, query url.Values, header http.Header, body io.Reader) (*http.Request, error) {
+	requestURL := c.baseURL
+	requestURL.Path = path.Join(requestURL.Path, requestPath)
+	// escaped segments, e.g. a name containing a slash, are kept as they are
+	if strings.Contains(requestPath, "%") {
+		escaped := path.Join(c.baseURL.EscapedPath(), requestPath)
+		if unescaped, err := url.PathUnescape(escaped); err == nil {
+			requestURL.Path, requestURL.RawPath = unescaped, escaped
+		}
+	}
+	requestURL.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, method, requestURL.String(), body)
 	if err != nil {
 		return req, err
 	}
-	if c.key != "" {
-		req.Header.Add("Authorization", c.key)
+	if c.auth != nil {
+		if err := c.auth.Authenticate(req); err != nil {
+			return nil, err
+		}
+	}
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if c.orgID > 0 {
+		req.Header.Set("X-Grafana-Org-Id", strconv.FormatInt(c.orgID, 10))
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
 	}
 
 	if os.Getenv("GF_LOG") != "" {
 		if body == nil {
-			log.Printf("request (%s) to %s with no body data", method, url.String())
+			log.Printf("request (%s) to %s with no body data", method, requestURL.String())
 		} else {
-			log.Printf("request (%s) to %s with body data: %s", method, url.String(), body.(*bytes.Buffer).String())
+			log.Printf("request (%s) to %s with body data: %s", method, requestURL.String(), body.(*bytes.Buffer).String())
 		}
 	}
 
-	req.Header.Add("Content-Type", "application/json")
+	for key, values := range header {
+		if _, ok := req.Header[http.CanonicalHeaderKey(key)]; ok {
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	return req, err
 }
+
+// send sends the request, retrying it as configured by the retry policy.
+// The body is buffered so it can be replayed for every attempt.
+// Every attempt has to pass the rate and concurrency limits.
+func (c *Client) send(ctx context.Context, method, requestPath string, query url.Values, header http.Header, body io.Reader) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	policy := c.config.Retry
+	for attempt := 1; ; attempt++ {
+		var attemptBody io.Reader
+		if body != nil {
+			attemptBody = bytes.NewBuffer(payload)
+		}
+		req, err := c.newRequest(ctx, method, requestPath, query, header, attemptBody)
+		if err != nil {
+			return nil, err
+		}
+		release, err := c.limiter.wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.Do(req)
+		if err != nil {
+			release()
+		} else {
+			resp.Body = &releaseBody{resp.Body, release}
+		}
+		if !policy.shouldRetry(ctx, method, attempt, resp, err) {
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if os.Getenv("GF_LOG") != "" {
+			log.Printf("retrying request (%s) to %s in %s, attempt %d failed", method, requestPath, wait, attempt)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// do sends the request and returns the response if Grafana answered with a
+// 2xx status code, any other status is turned into an *APIError.
+// The caller has to close the body of the returned response.
+func (c *Client) do(ctx context.Context, method, requestPath string, query url.Values, body io.Reader) (*http.Response, error) {
+	resp, err := c.send(ctx, method, requestPath, query, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+		return nil, newAPIError(method, requestPath, resp.StatusCode, data)
+	}
+	return resp, nil
+}
+
+// request sends the request and decodes the JSON response into responseStruct,
+// the response is discarded if responseStruct is nil.
+func (c *Client) request(ctx context.Context, method, requestPath string, query url.Values, body io.Reader, responseStruct interface{}) error {
+	data, err := c.requestRaw(ctx, method, requestPath, query, body)
+	if err != nil {
+		return err
+	}
+	if responseStruct == nil {
+		return nil
+	}
+	return json.Unmarshal(data, responseStruct)
+}
+
+// requestRaw sends the request and returns the response body.
+func (c *Client) requestRaw(ctx context.Context, method, requestPath string, query url.Values, body io.Reader) ([]byte, error) {
+	resp, err := c.do(ctx, method, requestPath, query, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if os.Getenv("GF_LOG") != "" {
+		log.Printf("response (%s) from %s with status %d and body data: %s", method, requestPath, resp.StatusCode, data)
+	}
+	return data, nil
+}
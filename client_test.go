@@ -0,0 +1,205 @@
+package gapi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// gapiBlockingTestTools returns a server whose handler only returns once the
+// request has been aborted by the client or the returned release func is called.
+func gapiBlockingTestTools(t *testing.T) (*httptest.Server, *Client, func()) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+
+	client, err := New("my-key", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, client, func() { close(release) }
+}
+
+func TestContextCancelAbortsRequest(t *testing.T) {
+	server, client, release := gapiBlockingTestTools(t)
+	defer server.Close()
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetDashboardContext(ctx, "nErXDvCkzz")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not aborted after the context was canceled")
+	}
+}
+
+func TestContextDeadlineAbortsRequest(t *testing.T) {
+	server, client, release := gapiBlockingTestTools(t)
+	defer server.Close()
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.NewDataSourceContext(ctx, &DataSource{Name: "foo"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("request took %s, deadline was not enforced", elapsed)
+	}
+}
+
+func TestContextCanceledBeforeRequest(t *testing.T) {
+	server, client, release := gapiBlockingTestTools(t)
+	defer server.Close()
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := client.DeleteFolderContext(ctx, "nErXDvCkzz"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestNewSplitsBasicAuthAtFirstColon(t *testing.T) {
+	server, _, rec := gapiRecorderTestTools(t, 200, getFoldersJSON)
+	defer server.Close()
+
+	client, err := New("admin:pass:word", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Folders(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &http.Request{Header: rec.last().Header}
+	user, password, ok := req.BasicAuth()
+	if !ok || user != "admin" || password != "pass:word" {
+		t.Errorf("expected basic auth admin/pass:word, got %q/%q", user, password)
+	}
+}
+
+func TestNewClientOptions(t *testing.T) {
+	server, _, rec := gapiRecorderTestTools(t, 200, getFoldersJSON)
+	defer server.Close()
+
+	client, err := NewClient(server.URL,
+		WithServiceAccountToken("glsa_token"),
+		WithOrgID(2),
+		WithHeader("X-Custom", "foo"),
+		WithUserAgent("gapi-test"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Folders(); err != nil {
+		t.Fatal(err)
+	}
+
+	h := rec.last().Header
+	expected := map[string]string{
+		"Authorization":    "Bearer glsa_token",
+		"X-Grafana-Org-Id": "2",
+		"X-Custom":         "foo",
+		"User-Agent":       "gapi-test",
+	}
+	for key, value := range expected {
+		if h.Get(key) != value {
+			t.Errorf("expected header %s to be %q, got %q", key, value, h.Get(key))
+		}
+	}
+}
+
+func TestNewClientAuthProvider(t *testing.T) {
+	server, _, rec := gapiRecorderTestTools(t, 200, getFoldersJSON)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithAuth(AuthProviderFunc(func(req *http.Request) error {
+		req.Header.Set("Authorization", "Custom secret")
+		return nil
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Folders(); err != nil {
+		t.Fatal(err)
+	}
+	if auth := rec.last().Header.Get("Authorization"); auth != "Custom secret" {
+		t.Errorf("expected the auth provider to set the header, got %q", auth)
+	}
+
+	failing, _ := NewClient(server.URL, WithAuth(AuthProviderFunc(func(req *http.Request) error {
+		return errors.New("no credentials")
+	})))
+	if _, err := failing.Folders(); err == nil || err.Error() != "no credentials" {
+		t.Errorf("expected the auth provider error, got %v", err)
+	}
+}
+
+func TestNewClientTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, getFoldersJSON)
+	}))
+	defer server.Close()
+
+	untrusted, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := untrusted.Folders(); err == nil {
+		t.Error("expected the self signed certificate to be rejected")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	client, err := NewClient(server.URL, WithTLSConfig(&tls.Config{RootCAs: pool}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Folders(); err != nil {
+		t.Error(err)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClientTLSConfigRequiresTransport(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}
+	_, err := NewClient("http://my-grafana.com", WithHTTPClient(httpClient), WithTLSConfig(&tls.Config{}))
+	if err == nil {
+		t.Error("expected an error for a transport that is not an *http.Transport")
+	}
+}
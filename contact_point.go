@@ -0,0 +1,91 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// ContactPoint is a unified alerting receiver, Settings depend on Type,
+// e.g. slack, email or webhook
+type ContactPoint struct {
+	UID                   string                 `json:"uid,omitempty"`
+	Name                  string                 `json:"name"`
+	Type                  string                 `json:"type"`
+	Settings              map[string]interface{} `json:"settings"`
+	DisableResolveMessage bool                   `json:"disableResolveMessage"`
+	Provenance            string                 `json:"provenance,omitempty"`
+}
+
+// ContactPoints lists all contact points
+func (c *Client) ContactPoints() ([]ContactPoint, error) {
+	return c.ContactPointsContext(context.Background())
+}
+
+// ContactPointsContext is like ContactPoints but takes a context for cancellation and deadlines.
+func (c *Client) ContactPointsContext(ctx context.Context) ([]ContactPoint, error) {
+	return c.contactPoints(ctx, nil)
+}
+
+// ContactPointsByName lists the contact points with the given name
+func (c *Client) ContactPointsByName(name string) ([]ContactPoint, error) {
+	return c.ContactPointsByNameContext(context.Background(), name)
+}
+
+// ContactPointsByNameContext is like ContactPointsByName but takes a context for cancellation and deadlines.
+func (c *Client) ContactPointsByNameContext(ctx context.Context, name string) ([]ContactPoint, error) {
+	params := url.Values{}
+	params.Add("name", name)
+	return c.contactPoints(ctx, params)
+}
+
+func (c *Client) contactPoints(ctx context.Context, params url.Values) ([]ContactPoint, error) {
+	points := make([]ContactPoint, 0)
+	err := c.request(ctx, "GET", "/api/v1/provisioning/contact-points", params, nil, &points)
+	return points, err
+}
+
+// NewContactPoint creates a contact point and returns it as stored by Grafana
+func (c *Client) NewContactPoint(p *ContactPoint) (*ContactPoint, error) {
+	return c.NewContactPointContext(context.Background(), p)
+}
+
+// NewContactPointContext is like NewContactPoint but takes a context for cancellation and deadlines.
+func (c *Client) NewContactPointContext(ctx context.Context, p *ContactPoint) (*ContactPoint, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	result := &ContactPoint{}
+	err = c.request(ctx, "POST", "/api/v1/provisioning/contact-points", nil, bytes.NewBuffer(data), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// UpdateContactPoint replaces the contact point with the UID of p
+func (c *Client) UpdateContactPoint(p *ContactPoint) error {
+	return c.UpdateContactPointContext(context.Background(), p)
+}
+
+// UpdateContactPointContext is like UpdateContactPoint but takes a context for cancellation and deadlines.
+func (c *Client) UpdateContactPointContext(ctx context.Context, p *ContactPoint) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PUT", fmt.Sprintf("/api/v1/provisioning/contact-points/%s", p.UID), nil, bytes.NewBuffer(data), nil)
+}
+
+// DeleteContactPoint deletes the contact point with the given UID
+func (c *Client) DeleteContactPoint(uid string) error {
+	return c.DeleteContactPointContext(context.Background(), uid)
+}
+
+// DeleteContactPointContext is like DeleteContactPoint but takes a context for cancellation and deadlines.
+func (c *Client) DeleteContactPointContext(ctx context.Context, uid string) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/v1/provisioning/contact-points/%s", uid), nil, nil, nil)
+}
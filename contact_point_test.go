@@ -0,0 +1,99 @@
+package gapi
+
+import (
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getContactPointsJSON = `
+[
+  {
+    "uid": "pwE4QTk4z",
+    "name": "infra-slack",
+    "type": "slack",
+    "settings": {
+      "recipient": "#infra-alerts",
+      "url": "[REDACTED]"
+    },
+    "disableResolveMessage": false,
+    "provenance": "api"
+  }
+]
+`
+	createdContactPointJSON = `
+{
+  "uid": "pwE4QTk4z",
+  "name": "infra-slack",
+  "type": "slack",
+  "settings": {
+    "recipient": "#infra-alerts",
+    "url": "[REDACTED]"
+  },
+  "disableResolveMessage": false,
+  "provenance": "api"
+}
+`
+)
+
+func TestContactPoints(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getContactPointsJSON)
+	defer server.Close()
+
+	points, err := client.ContactPointsByName("infra-slack")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/v1/provisioning/contact-points")
+
+	t.Log(pretty.PrettyFormat(points))
+
+	if len(points) != 1 || points[0].UID != "pwE4QTk4z" || points[0].Settings["recipient"] != "#infra-alerts" {
+		t.Error("Not correctly parsing returned contact points.")
+	}
+}
+
+func TestNewContactPoint(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 202, createdContactPointJSON)
+	defer server.Close()
+
+	point, err := client.NewContactPoint(&ContactPoint{
+		Name: "infra-slack",
+		Type: "slack",
+		Settings: map[string]interface{}{
+			"recipient": "#infra-alerts",
+			"url":       "https://hooks.slack.com/services/T000/B000/XXX",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("POST", "/api/v1/provisioning/contact-points")
+
+	if point.UID != "pwE4QTk4z" {
+		t.Error("contact point creation response should return the created contact point")
+	}
+}
+
+func TestUpdateContactPoint(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 202, "")
+	defer server.Close()
+
+	err := client.UpdateContactPoint(&ContactPoint{UID: "pwE4QTk4z", Name: "infra-slack", Type: "slack"})
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/v1/provisioning/contact-points/pwE4QTk4z")
+}
+
+func TestDeleteContactPoint(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 202, "")
+	defer server.Close()
+
+	err := client.DeleteContactPoint("pwE4QTk4z")
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/v1/provisioning/contact-points/pwE4QTk4z")
+}
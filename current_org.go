@@ -0,0 +1,238 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// OrgUserLookup is a user of the current org returned by LookupCurrentOrgUsers
+type OrgUserLookup struct {
+	UserId    int64  `json:"userId"`
+	Login     string `json:"login"`
+	AvatarUrl string `json:"avatarUrl"`
+}
+
+// SearchOrgUsers is a page of the current org user search
+type SearchOrgUsers struct {
+	TotalCount int64     `json:"totalCount"`
+	OrgUsers   []OrgUser `json:"orgUsers"`
+	Page       int64     `json:"page"`
+	PerPage    int64     `json:"perPage"`
+}
+
+// OrgInvite is a pending invitation to the current org
+type OrgInvite struct {
+	Id             int64      `json:"id"`
+	OrgId          int64      `json:"orgId"`
+	Name           string     `json:"name"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	InvitedByLogin string     `json:"invitedByLogin"`
+	InvitedByEmail string     `json:"invitedByEmail"`
+	InvitedByName  string     `json:"invitedByName"`
+	Code           string     `json:"code"`
+	Status         string     `json:"status"`
+	Url            string     `json:"url"`
+	EmailSent      bool       `json:"emailSent"`
+	EmailSentOn    *time.Time `json:"emailSentOn,omitempty"`
+	CreatedOn      *time.Time `json:"createdOn,omitempty"`
+}
+
+// NewOrgInvite invites a user to the current org, an existing user given by
+// LoginOrEmail is added right away instead
+type NewOrgInvite struct {
+	LoginOrEmail string `json:"loginOrEmail"`
+	Name         string `json:"name,omitempty"`
+	Role         string `json:"role"`
+	SendEmail    bool   `json:"sendEmail"`
+}
+
+// CurrentOrg returns the org of the client, see WithOrg
+func (c *Client) CurrentOrg() (Org, error) {
+	return c.CurrentOrgContext(context.Background())
+}
+
+// CurrentOrgContext is like CurrentOrg but takes a context for cancellation and deadlines.
+func (c *Client) CurrentOrgContext(ctx context.Context) (Org, error) {
+	org := Org{}
+	err := c.request(ctx, "GET", "/api/org", nil, nil, &org)
+	return org, err
+}
+
+// UpdateCurrentOrg renames the current org
+func (c *Client) UpdateCurrentOrg(name string) error {
+	return c.UpdateCurrentOrgContext(context.Background(), name)
+}
+
+// UpdateCurrentOrgContext is like UpdateCurrentOrg but takes a context for cancellation and deadlines.
+func (c *Client) UpdateCurrentOrgContext(ctx context.Context, name string) error {
+	data, err := json.Marshal(map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PUT", "/api/org", nil, bytes.NewBuffer(data), nil)
+}
+
+// UpdateCurrentOrgAddress replaces the address of the current org
+func (c *Client) UpdateCurrentOrgAddress(address OrgAddress) error {
+	return c.UpdateCurrentOrgAddressContext(context.Background(), address)
+}
+
+// UpdateCurrentOrgAddressContext is like UpdateCurrentOrgAddress but takes a context for cancellation and deadlines.
+func (c *Client) UpdateCurrentOrgAddressContext(ctx context.Context, address OrgAddress) error {
+	data, err := json.Marshal(address)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PUT", "/api/org/address", nil, bytes.NewBuffer(data), nil)
+}
+
+// CurrentOrgUsers lists the users of the current org
+func (c *Client) CurrentOrgUsers() ([]OrgUser, error) {
+	return c.CurrentOrgUsersContext(context.Background())
+}
+
+// CurrentOrgUsersContext is like CurrentOrgUsers but takes a context for cancellation and deadlines.
+func (c *Client) CurrentOrgUsersContext(ctx context.Context) ([]OrgUser, error) {
+	users := make([]OrgUser, 0)
+	err := c.request(ctx, "GET", "/api/org/users", nil, nil, &users)
+	return users, err
+}
+
+// LookupCurrentOrgUsers returns up to limit users of the current org whose
+// login, email or name matches query, it only requires the viewer role
+func (c *Client) LookupCurrentOrgUsers(query string, limit int64) ([]OrgUserLookup, error) {
+	return c.LookupCurrentOrgUsersContext(context.Background(), query, limit)
+}
+
+// LookupCurrentOrgUsersContext is like LookupCurrentOrgUsers but takes a context for cancellation and deadlines.
+func (c *Client) LookupCurrentOrgUsersContext(ctx context.Context, query string, limit int64) ([]OrgUserLookup, error) {
+	params := url.Values{}
+	params.Add("query", query)
+	if limit > 0 {
+		params.Add("limit", strconv.FormatInt(limit, 10))
+	}
+	users := make([]OrgUserLookup, 0)
+	err := c.request(ctx, "GET", "/api/org/users/lookup", params, nil, &users)
+	return users, err
+}
+
+// SearchCurrentOrgUsers searches the users of the current org, page starts at 1
+func (c *Client) SearchCurrentOrgUsers(query string, page, perPage int64) (*SearchOrgUsers, error) {
+	return c.SearchCurrentOrgUsersContext(context.Background(), query, page, perPage)
+}
+
+// SearchCurrentOrgUsersContext is like SearchCurrentOrgUsers but takes a context for cancellation and deadlines.
+func (c *Client) SearchCurrentOrgUsersContext(ctx context.Context, query string, page, perPage int64) (*SearchOrgUsers, error) {
+	params := url.Values{}
+	if query != "" {
+		params.Add("query", query)
+	}
+	if page > 0 {
+		params.Add("page", strconv.FormatInt(page, 10))
+	}
+	if perPage > 0 {
+		params.Add("perpage", strconv.FormatInt(perPage, 10))
+	}
+	result := &SearchOrgUsers{}
+	err := c.request(ctx, "GET", "/api/org/users/search", params, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// OrgUserIterator pages through all users of the current org matching a search
+//
+//	it := client.NewOrgUserIterator("", 0)
+//	for it.Next() {
+//		user := it.OrgUser()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type OrgUserIterator struct {
+	pager
+	users []OrgUser
+}
+
+// NewOrgUserIterator returns an iterator over all users of the current org
+// matching query, perPage is the page size, 1000 if not set
+func (c *Client) NewOrgUserIterator(query string, perPage int64) *OrgUserIterator {
+	return c.NewOrgUserIteratorContext(context.Background(), query, perPage)
+}
+
+// NewOrgUserIteratorContext is like NewOrgUserIterator but takes a context for cancellation and deadlines.
+func (c *Client) NewOrgUserIteratorContext(ctx context.Context, query string, perPage int64) *OrgUserIterator {
+	if perPage <= 0 {
+		perPage = defaultSearchLimit
+	}
+	it := &OrgUserIterator{}
+	it.pager = newPager(1, perPage, func(page int64) (int, error) {
+		result, err := c.SearchCurrentOrgUsersContext(ctx, query, page, perPage)
+		if err != nil {
+			return 0, err
+		}
+		it.users = result.OrgUsers
+		return len(result.OrgUsers), nil
+	})
+	return it
+}
+
+// Next advances to the next user, it returns false when all users have been
+// read or an error occurred
+func (it *OrgUserIterator) Next() bool {
+	return it.next()
+}
+
+// OrgUser returns the current user
+func (it *OrgUserIterator) OrgUser() OrgUser {
+	return it.users[it.i]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *OrgUserIterator) Err() error {
+	return it.err
+}
+
+// OrgInvites lists the pending invites of the current org
+func (c *Client) OrgInvites() ([]OrgInvite, error) {
+	return c.OrgInvitesContext(context.Background())
+}
+
+// OrgInvitesContext is like OrgInvites but takes a context for cancellation and deadlines.
+func (c *Client) OrgInvitesContext(ctx context.Context) ([]OrgInvite, error) {
+	invites := make([]OrgInvite, 0)
+	err := c.request(ctx, "GET", "/api/org/invites", nil, nil, &invites)
+	return invites, err
+}
+
+// CreateOrgInvite invites a user to the current org
+func (c *Client) CreateOrgInvite(invite NewOrgInvite) error {
+	return c.CreateOrgInviteContext(context.Background(), invite)
+}
+
+// CreateOrgInviteContext is like CreateOrgInvite but takes a context for cancellation and deadlines.
+func (c *Client) CreateOrgInviteContext(ctx context.Context, invite NewOrgInvite) error {
+	data, err := json.Marshal(invite)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "POST", "/api/org/invites", nil, bytes.NewBuffer(data), nil)
+}
+
+// RevokeOrgInvite revokes the pending invite with the given code
+func (c *Client) RevokeOrgInvite(code string) error {
+	return c.RevokeOrgInviteContext(context.Background(), code)
+}
+
+// RevokeOrgInviteContext is like RevokeOrgInvite but takes a context for cancellation and deadlines.
+func (c *Client) RevokeOrgInviteContext(ctx context.Context, code string) error {
+	return c.request(ctx, "PATCH", fmt.Sprintf("/api/org/invites/%s/revoke", code), nil, nil, nil)
+}
@@ -0,0 +1,177 @@
+package gapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getCurrentOrgJSON = `
+{
+  "id": 1,
+  "name": "Main Org.",
+  "address": {
+    "address1": "Main Street 1",
+    "address2": "",
+    "city": "Berlin",
+    "zipCode": "10115",
+    "state": "",
+    "country": "Germany"
+  }
+}
+`
+	lookupCurrentOrgUsersJSON = `[{"userId":1,"login":"admin","avatarUrl":"/avatar/46d229b033af06a191ff2267bca9ae56"}]`
+	getOrgInvitesJSON         = `
+[
+  {
+    "id": 1,
+    "orgId": 1,
+    "name": "Jane",
+    "email": "jane@localhost",
+    "role": "Editor",
+    "invitedByLogin": "admin",
+    "invitedByEmail": "admin@localhost",
+    "invitedByName": "Admin",
+    "code": "c7bPQgbBKF",
+    "status": "Pending",
+    "url": "http://localhost:3000/invite/c7bPQgbBKF",
+    "emailSent": true,
+    "emailSentOn": "2019-09-09T11:31:26+02:00",
+    "createdOn": "2019-09-09T11:31:26+02:00"
+  }
+]
+`
+)
+
+func TestCurrentOrg(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getCurrentOrgJSON)
+	defer server.Close()
+
+	org, err := client.CurrentOrg()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/org")
+
+	t.Log(pretty.PrettyFormat(org))
+
+	if org.Id != 1 || org.Name != "Main Org." || org.Address == nil || org.Address.City != "Berlin" {
+		t.Error("Not correctly parsing returned current org.")
+	}
+}
+
+func TestUpdateCurrentOrg(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"Organization updated"}`)
+	defer server.Close()
+
+	if err := client.UpdateCurrentOrg("Main Org."); err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/org")
+	if err := client.UpdateCurrentOrgAddress(OrgAddress{City: "Berlin", Country: "Germany"}); err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/org/address")
+}
+
+func TestLookupCurrentOrgUsers(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, lookupCurrentOrgUsersJSON)
+	defer server.Close()
+
+	users, err := client.LookupCurrentOrgUsers("adm", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q := rec.expect("GET", "/api/org/users/lookup").Query; q.Get("query") != "adm" || q.Get("limit") != "10" {
+		t.Errorf("unexpected lookup query %v", q)
+	}
+	if len(users) != 1 || users[0].UserId != 1 || users[0].Login != "admin" {
+		t.Error("Not correctly parsing returned org user lookup.")
+	}
+}
+
+func TestOrgUserIterator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/org/users/search" || r.URL.Query().Get("query") != "a" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"totalCount":3,"orgUsers":[{"userId":1,"login":"admin"},{"userId":2,"login":"jane"}],"page":1,"perPage":2}`)
+		case "2":
+			fmt.Fprint(w, `{"totalCount":3,"orgUsers":[{"userId":3,"login":"joe"}],"page":2,"perPage":2}`)
+		default:
+			t.Errorf("unexpected request for page %s", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New("my-key", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logins []string
+	it := client.NewOrgUserIterator("a", 2)
+	for it.Next() {
+		logins = append(logins, it.OrgUser().Login)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(logins, []string{"admin", "jane", "joe"}) {
+		t.Errorf("expected to iterate over all org users, got %v", logins)
+	}
+}
+
+func TestOrgInvites(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getOrgInvitesJSON)
+	defer server.Close()
+
+	invites, err := client.OrgInvites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/org/invites")
+
+	t.Log(pretty.PrettyFormat(invites))
+
+	if len(invites) != 1 || invites[0].Code != "c7bPQgbBKF" || invites[0].Role != "Editor" || !invites[0].EmailSent || invites[0].CreatedOn == nil {
+		t.Error("Not correctly parsing returned org invites.")
+	}
+}
+
+func TestCreateOrgInvite(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"Invited jane@localhost to Main Org."}`)
+	defer server.Close()
+
+	err := client.CreateOrgInvite(NewOrgInvite{LoginOrEmail: "jane@localhost", Role: "Editor", SendEmail: false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var invite map[string]interface{}
+	if err := json.Unmarshal([]byte(rec.expect("POST", "/api/org/invites").Body), &invite); err != nil {
+		t.Fatal(err)
+	}
+	if invite["loginOrEmail"] != "jane@localhost" || invite["role"] != "Editor" || invite["sendEmail"] != false {
+		t.Errorf("unexpected invite payload %v", invite)
+	}
+}
+
+func TestRevokeOrgInvite(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"Invite revoked"}`)
+	defer server.Close()
+
+	if err := client.RevokeOrgInvite("c7bPQgbBKF"); err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("PATCH", "/api/org/invites/c7bPQgbBKF/revoke")
+}
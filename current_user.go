@@ -0,0 +1,117 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// CurrentUser returns the user the client is authenticated as
+func (c *Client) CurrentUser() (User, error) {
+	return c.CurrentUserContext(context.Background())
+}
+
+// CurrentUserContext is like CurrentUser but takes a context for cancellation and deadlines.
+func (c *Client) CurrentUserContext(ctx context.Context) (User, error) {
+	user := User{}
+	err := c.request(ctx, "GET", "/api/user", nil, nil, &user)
+	return user, err
+}
+
+// CurrentUserOrgs lists the organizations of the current user
+func (c *Client) CurrentUserOrgs() ([]UserOrg, error) {
+	return c.CurrentUserOrgsContext(context.Background())
+}
+
+// CurrentUserOrgsContext is like CurrentUserOrgs but takes a context for cancellation and deadlines.
+func (c *Client) CurrentUserOrgsContext(ctx context.Context) ([]UserOrg, error) {
+	orgs := make([]UserOrg, 0)
+	err := c.request(ctx, "GET", "/api/user/orgs", nil, nil, &orgs)
+	return orgs, err
+}
+
+// SwitchCurrentUserOrg changes the active organization of the current user.
+// Use WithOrg to send a single request in the context of another organization.
+func (c *Client) SwitchCurrentUserOrg(orgID int64) error {
+	return c.SwitchCurrentUserOrgContext(context.Background(), orgID)
+}
+
+// SwitchCurrentUserOrgContext is like SwitchCurrentUserOrg but takes a context for cancellation and deadlines.
+func (c *Client) SwitchCurrentUserOrgContext(ctx context.Context, orgID int64) error {
+	return c.request(ctx, "POST", fmt.Sprintf("/api/user/using/%d", orgID), nil, nil, nil)
+}
+
+// StarDashboard stars the dashboard with the given id for the current user
+func (c *Client) StarDashboard(dashboardID int64) error {
+	return c.StarDashboardContext(context.Background(), dashboardID)
+}
+
+// StarDashboardContext is like StarDashboard but takes a context for cancellation and deadlines.
+func (c *Client) StarDashboardContext(ctx context.Context, dashboardID int64) error {
+	return c.request(ctx, "POST", fmt.Sprintf("/api/user/stars/dashboard/%d", dashboardID), nil, nil, nil)
+}
+
+// UnstarDashboard removes the star of the current user from the dashboard with the given id
+func (c *Client) UnstarDashboard(dashboardID int64) error {
+	return c.UnstarDashboardContext(context.Background(), dashboardID)
+}
+
+// UnstarDashboardContext is like UnstarDashboard but takes a context for cancellation and deadlines.
+func (c *Client) UnstarDashboardContext(ctx context.Context, dashboardID int64) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/user/stars/dashboard/%d", dashboardID), nil, nil, nil)
+}
+
+// CurrentUserPreferences returns the preferences of the current user
+func (c *Client) CurrentUserPreferences() (*Preferences, error) {
+	return c.CurrentUserPreferencesContext(context.Background())
+}
+
+// CurrentUserPreferencesContext is like CurrentUserPreferences but takes a context for cancellation and deadlines.
+func (c *Client) CurrentUserPreferencesContext(ctx context.Context) (*Preferences, error) {
+	preferences := &Preferences{}
+	err := c.request(ctx, "GET", "/api/user/preferences", nil, nil, preferences)
+	if err != nil {
+		return nil, err
+	}
+	return preferences, err
+}
+
+// UpdateCurrentUserPreferences replaces the preferences of the current user
+func (c *Client) UpdateCurrentUserPreferences(preferences Preferences) error {
+	return c.UpdateCurrentUserPreferencesContext(context.Background(), preferences)
+}
+
+// UpdateCurrentUserPreferencesContext is like UpdateCurrentUserPreferences but takes a context for cancellation and deadlines.
+func (c *Client) UpdateCurrentUserPreferencesContext(ctx context.Context, preferences Preferences) error {
+	return c.sendPreferences(ctx, "PUT", "/api/user/preferences", preferences)
+}
+
+// PatchCurrentUserPreferences changes the preferences of the current user that are set in preferences
+func (c *Client) PatchCurrentUserPreferences(preferences Preferences) error {
+	return c.PatchCurrentUserPreferencesContext(context.Background(), preferences)
+}
+
+// PatchCurrentUserPreferencesContext is like PatchCurrentUserPreferences but takes a context for cancellation and deadlines.
+func (c *Client) PatchCurrentUserPreferencesContext(ctx context.Context, preferences Preferences) error {
+	return c.sendPreferences(ctx, "PATCH", "/api/user/preferences", preferences)
+}
+
+// UpdateCurrentUserPassword changes the password of the current user, it
+// fails for users authenticated by an external provider
+func (c *Client) UpdateCurrentUserPassword(oldPassword, newPassword string) error {
+	return c.UpdateCurrentUserPasswordContext(context.Background(), oldPassword, newPassword)
+}
+
+// UpdateCurrentUserPasswordContext is like UpdateCurrentUserPassword but takes a context for cancellation and deadlines.
+func (c *Client) UpdateCurrentUserPasswordContext(ctx context.Context, oldPassword, newPassword string) error {
+	data, err := json.Marshal(map[string]string{
+		"oldPassword": oldPassword,
+		"newPassword": newPassword,
+		"confirmNew":  newPassword,
+	})
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PUT", "/api/user/password", nil, bytes.NewBuffer(data), nil)
+}
@@ -0,0 +1,122 @@
+package gapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getCurrentUserJSON = `
+{
+  "id": 1,
+  "email": "admin@localhost",
+  "name": "Admin",
+  "login": "admin",
+  "theme": "light",
+  "orgId": 1,
+  "isGrafanaAdmin": true,
+  "isDisabled": false,
+  "isExternal": false,
+  "authLabels": [],
+  "updatedAt": "2019-09-09T11:31:26+02:00",
+  "createdAt": "2019-09-09T11:31:26+02:00",
+  "avatarUrl": ""
+}
+`
+	getCurrentUserPreferencesJSON = `{"theme":"dark","homeDashboardId":217,"timezone":"utc"}`
+)
+
+func TestCurrentUser(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getCurrentUserJSON)
+	defer server.Close()
+
+	user, err := client.CurrentUser()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/user")
+
+	t.Log(pretty.PrettyFormat(user))
+
+	if user.Login != "admin" || !user.IsAdmin || user.OrgId != 1 {
+		t.Error("Not correctly parsing returned current user.")
+	}
+}
+
+func TestCurrentUserOrgs(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getUserOrgsJSON)
+	defer server.Close()
+
+	orgs, err := client.CurrentUserOrgs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/user/orgs")
+
+	if len(orgs) != 2 || orgs[0].Name != "Main Org." || orgs[0].Role != "Admin" {
+		t.Error("Not correctly parsing returned current user orgs.")
+	}
+}
+
+func TestSwitchCurrentUserOrg(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"Active organization changed"}`)
+	defer server.Close()
+
+	if err := client.SwitchCurrentUserOrg(2); err != nil {
+		t.Error(err)
+	}
+	rec.expect("POST", "/api/user/using/2")
+}
+
+func TestStarDashboard(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"Dashboard starred!"}`)
+	defer server.Close()
+
+	if err := client.StarDashboard(1); err != nil {
+		t.Error(err)
+	}
+	rec.expect("POST", "/api/user/stars/dashboard/1")
+	if err := client.UnstarDashboard(1); err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/user/stars/dashboard/1")
+}
+
+func TestCurrentUserPreferences(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getCurrentUserPreferencesJSON)
+	defer server.Close()
+
+	preferences, err := client.CurrentUserPreferences()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/user/preferences")
+
+	if preferences.Theme != "dark" || preferences.HomeDashboardID != 217 || preferences.Timezone != "utc" {
+		t.Error("Not correctly parsing returned current user preferences.")
+	}
+
+	if err := client.UpdateCurrentUserPreferences(*preferences); err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/user/preferences")
+}
+
+func TestUpdateCurrentUserPassword(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"User password changed"}`)
+	defer server.Close()
+
+	if err := client.UpdateCurrentUserPassword("old", "new"); err != nil {
+		t.Fatal(err)
+	}
+
+	payload := map[string]string{}
+	if err := json.Unmarshal([]byte(rec.expect("PUT", "/api/user/password").Body), &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload["oldPassword"] != "old" || payload["newPassword"] != "new" || payload["confirmNew"] != "new" {
+		t.Errorf("unexpected password payload %v", payload)
+	}
+}
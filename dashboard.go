@@ -2,13 +2,9 @@ package gapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"net/url"
-	"os"
 )
 
 type DashboardMeta struct {
@@ -135,11 +131,16 @@ type DashboardModel struct {
 
 // DashboardDeleteResponse grafana response for delete dashboard
 type DashboardDeleteResponse struct {
-	Title string `json:title`
+	Title string `json:"title"`
 }
 
 // Deprecated: use NewDashboard instead
 func (c *Client) SaveDashboard(model map[string]interface{}, overwrite bool) (*DashboardSaveResponse, error) {
+	return c.SaveDashboardContext(context.Background(), model, overwrite)
+}
+
+// SaveDashboardContext is like SaveDashboard but takes a context for cancellation and deadlines.
+func (c *Client) SaveDashboardContext(ctx context.Context, model map[string]interface{}, overwrite bool) (*DashboardSaveResponse, error) {
 	wrapper := map[string]interface{}{
 		"dashboard": model,
 		"overwrite": overwrite,
@@ -148,116 +149,80 @@ func (c *Client) SaveDashboard(model map[string]interface{}, overwrite bool) (*D
 	if err != nil {
 		return nil, err
 	}
-	req, err := c.newRequest("POST", "/api/dashboards/db", nil, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode != 200 {
-		data, _ = ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("status: %d, body: %s", resp.StatusCode, data)
-	}
-
-	data, err = ioutil.ReadAll(resp.Body)
+	result := &DashboardSaveResponse{}
+	err = c.request(ctx, "POST", "/api/dashboards/db", nil, bytes.NewBuffer(data), result)
 	if err != nil {
 		return nil, err
 	}
-
-	result := &DashboardSaveResponse{}
-	err = json.Unmarshal(data, &result)
 	return result, err
 }
 
 func (c *Client) NewDashboard(dashboard Dashboard) (*DashboardSaveResponse, error) {
-	data, err := json.Marshal(dashboard)
-	if err != nil {
-		return nil, err
-	}
-	req, err := c.newRequest("POST", "/api/dashboards/import", nil, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, err
-	}
+	return c.NewDashboardContext(context.Background(), dashboard)
+}
 
-	resp, err := c.Do(req)
+// NewDashboardContext is like NewDashboard but takes a context for cancellation and deadlines.
+func (c *Client) NewDashboardContext(ctx context.Context, dashboard Dashboard) (*DashboardSaveResponse, error) {
+	data, err := json.Marshal(dashboard)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
-	}
-
-	data, err = ioutil.ReadAll(resp.Body)
+	result := &DashboardSaveResponse{}
+	err = c.request(ctx, "POST", "/api/dashboards/import", nil, bytes.NewBuffer(data), result)
 	if err != nil {
 		return nil, err
 	}
-
-	result := &DashboardSaveResponse{}
-	err = json.Unmarshal(data, &result)
 	return result, err
 }
 
-// SearchDashboard search a dashboard in Grafana
+// SearchDashboard search a dashboard in Grafana, folderID is passed as is
+// to the folderIds parameter. Use Search to filter by several folders.
 func (c *Client) SearchDashboard(query string, folderID string) ([]Dashboards, error) {
-	dashboards := make([]Dashboards, 0)
-	path := "/api/search"
+	return c.SearchDashboardContext(context.Background(), query, folderID)
+}
 
-	params := url.Values{}
-	params.Add("type", "dash-db")
+// SearchDashboardContext is like SearchDashboard but takes a context for cancellation and deadlines.
+func (c *Client) SearchDashboardContext(ctx context.Context, query string, folderID string) ([]Dashboards, error) {
+	dashboards := make([]Dashboards, 0)
+	params := SearchQuery{Type: SearchTypeDashboard}.values()
 	params.Add("query", query)
 	params.Add("folderIds", folderID)
 
-	req, err := c.newRequest("GET", path, params, nil)
+	hits := make([]SearchHit, 0)
+	err := c.request(ctx, "GET", "/api/search", params, nil, &hits)
 	if err != nil {
 		return dashboards, err
 	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return dashboards, err
-	}
-	if resp.StatusCode != 200 {
-		return dashboards, errors.New(resp.Status)
-	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return dashboards, err
+	for _, hit := range hits {
+		dashboards = append(dashboards, Dashboards{
+			ID:          hit.ID,
+			UID:         hit.UID,
+			Title:       hit.Title,
+			URI:         hit.URI,
+			URL:         hit.URL,
+			Starred:     hit.IsStarred,
+			FolderID:    hit.FolderID,
+			FolderUID:   hit.FolderUID,
+			FolderTitle: hit.FolderTitle,
+		})
 	}
-
-	err = json.Unmarshal(data, &dashboards)
-
 	return dashboards, err
 }
 
 // GetDashboard get a dashboard by UID
 func (c *Client) GetDashboard(uid string) (*Dashboard, error) {
-	path := fmt.Sprintf("/api/dashboards/uid/%s", uid)
-	req, err := c.newRequest("GET", path, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
-	}
+	return c.GetDashboardContext(context.Background(), uid)
+}
 
-	data, err := ioutil.ReadAll(resp.Body)
+// GetDashboardContext is like GetDashboard but takes a context for cancellation and deadlines.
+func (c *Client) GetDashboardContext(ctx context.Context, uid string) (*Dashboard, error) {
+	path := fmt.Sprintf("/api/dashboards/uid/%s", uid)
+	result := &Dashboard{}
+	err := c.request(ctx, "GET", path, nil, nil, result)
 	if err != nil {
 		return nil, err
 	}
-
-	result := &Dashboard{}
-	err = json.Unmarshal(data, &result)
 	result.Folder = result.Meta.Folder
-	if os.Getenv("GF_LOG") != "" {
-		log.Printf("got back dashboard response  %s", data)
-	}
 	// the dashboard uid is not a part of the response
 	result.Meta.UID = uid
 
@@ -266,55 +231,31 @@ func (c *Client) GetDashboard(uid string) (*Dashboard, error) {
 
 // Deprecated: use GetDashboard instead
 func (c *Client) Dashboard(slug string) (*Dashboard, error) {
-	path := fmt.Sprintf("/api/dashboards/db/%s", slug)
-	req, err := c.newRequest("GET", path, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
-	}
+	return c.DashboardContext(context.Background(), slug)
+}
 
-	data, err := ioutil.ReadAll(resp.Body)
+// DashboardContext is like Dashboard but takes a context for cancellation and deadlines.
+func (c *Client) DashboardContext(ctx context.Context, slug string) (*Dashboard, error) {
+	path := fmt.Sprintf("/api/dashboards/db/%s", slug)
+	result := &Dashboard{}
+	err := c.request(ctx, "GET", path, nil, nil, result)
 	if err != nil {
 		return nil, err
 	}
-
-	result := &Dashboard{}
-	err = json.Unmarshal(data, &result)
 	result.Folder = result.Meta.Folder
-	if os.Getenv("GF_LOG") != "" {
-		log.Printf("got back dashboard response  %s", data)
-	}
 	return result, err
 }
 
 // DeleteDashboard deletes a grafana dashoboard
 func (c *Client) DeleteDashboard(uid string) (string, error) {
+	return c.DeleteDashboardContext(context.Background(), uid)
+}
+
+// DeleteDashboardContext is like DeleteDashboard but takes a context for cancellation and deadlines.
+func (c *Client) DeleteDashboardContext(ctx context.Context, uid string) (string, error) {
 	deleted := &DashboardDeleteResponse{}
 	path := fmt.Sprintf("/api/dashboards/uid/%s", uid)
-	req, err := c.newRequest("DELETE", path, nil, nil)
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return "", err
-	}
-	if resp.StatusCode != 200 {
-		return "", errors.New(resp.Status)
-	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-	err = json.Unmarshal(data, &deleted)
+	err := c.request(ctx, "DELETE", path, nil, nil, deleted)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,127 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DashboardVersion is an entry of the version history of a dashboard,
+// Data is only returned when fetching a single version
+type DashboardVersion struct {
+	ID            int64                  `json:"id"`
+	DashboardID   int64                  `json:"dashboardId"`
+	ParentVersion int64                  `json:"parentVersion"`
+	RestoredFrom  int64                  `json:"restoredFrom"`
+	Version       int64                  `json:"version"`
+	Created       time.Time              `json:"created"`
+	CreatedBy     string                 `json:"createdBy"`
+	Message       string                 `json:"message"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+}
+
+// DashboardDiffTarget identifies one side of a dashboard diff, either a
+// saved version or an unsaved dashboard model
+type DashboardDiffTarget struct {
+	DashboardID      int64                  `json:"dashboardId"`
+	Version          int64                  `json:"version"`
+	UnsavedDashboard map[string]interface{} `json:"unsavedDashboard,omitempty"`
+}
+
+// DashboardDiff holds the diff of two dashboard versions as rendered by Grafana
+type DashboardDiff struct {
+	// JSON is the annotated JSON diff
+	JSON string
+	// Basic is the summary of the changes
+	Basic string
+}
+
+// DashboardVersions lists the versions of a dashboard, newest first.
+// limit and start are ignored if zero
+func (c *Client) DashboardVersions(dashboardID, limit, start int64) ([]DashboardVersion, error) {
+	return c.DashboardVersionsContext(context.Background(), dashboardID, limit, start)
+}
+
+// DashboardVersionsContext is like DashboardVersions but takes a context for cancellation and deadlines.
+func (c *Client) DashboardVersionsContext(ctx context.Context, dashboardID, limit, start int64) ([]DashboardVersion, error) {
+	versions := make([]DashboardVersion, 0)
+	params := url.Values{}
+	if limit > 0 {
+		params.Add("limit", strconv.FormatInt(limit, 10))
+	}
+	if start > 0 {
+		params.Add("start", strconv.FormatInt(start, 10))
+	}
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/dashboards/id/%d/versions", dashboardID), params, nil, &versions)
+	return versions, err
+}
+
+// DashboardVersion returns a single version of a dashboard including its model
+func (c *Client) DashboardVersion(dashboardID, version int64) (*DashboardVersion, error) {
+	return c.DashboardVersionContext(context.Background(), dashboardID, version)
+}
+
+// DashboardVersionContext is like DashboardVersion but takes a context for cancellation and deadlines.
+func (c *Client) DashboardVersionContext(ctx context.Context, dashboardID, version int64) (*DashboardVersion, error) {
+	result := &DashboardVersion{}
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/dashboards/id/%d/versions/%d", dashboardID, version), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// RestoreDashboardVersion saves the given version as the newest version of the dashboard
+func (c *Client) RestoreDashboardVersion(dashboardID, version int64) (*DashboardSaveResponse, error) {
+	return c.RestoreDashboardVersionContext(context.Background(), dashboardID, version)
+}
+
+// RestoreDashboardVersionContext is like RestoreDashboardVersion but takes a context for cancellation and deadlines.
+func (c *Client) RestoreDashboardVersionContext(ctx context.Context, dashboardID, version int64) (*DashboardSaveResponse, error) {
+	data, err := json.Marshal(map[string]int64{"version": version})
+	if err != nil {
+		return nil, err
+	}
+	result := &DashboardSaveResponse{}
+	err = c.request(ctx, "POST", fmt.Sprintf("/api/dashboards/id/%d/restore", dashboardID), nil, bytes.NewBuffer(data), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// CalculateDashboardDiff compares two dashboard versions, it returns both
+// the json and the basic diff
+func (c *Client) CalculateDashboardDiff(base, newer DashboardDiffTarget) (*DashboardDiff, error) {
+	return c.CalculateDashboardDiffContext(context.Background(), base, newer)
+}
+
+// CalculateDashboardDiffContext is like CalculateDashboardDiff but takes a context for cancellation and deadlines.
+func (c *Client) CalculateDashboardDiffContext(ctx context.Context, base, newer DashboardDiffTarget) (*DashboardDiff, error) {
+	jsonDiff, err := c.calculateDashboardDiff(ctx, base, newer, "json")
+	if err != nil {
+		return nil, err
+	}
+	basicDiff, err := c.calculateDashboardDiff(ctx, base, newer, "basic")
+	if err != nil {
+		return nil, err
+	}
+	return &DashboardDiff{JSON: jsonDiff, Basic: basicDiff}, nil
+}
+
+func (c *Client) calculateDashboardDiff(ctx context.Context, base, newer DashboardDiffTarget, diffType string) (string, error) {
+	data, err := json.Marshal(struct {
+		Base     DashboardDiffTarget `json:"base"`
+		New      DashboardDiffTarget `json:"new"`
+		DiffType string              `json:"diffType"`
+	}{base, newer, diffType})
+	if err != nil {
+		return "", err
+	}
+	diff, err := c.requestRaw(ctx, "POST", "/api/dashboards/calculate-diff", nil, bytes.NewBuffer(data))
+	return string(diff), err
+}
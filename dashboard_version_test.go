@@ -0,0 +1,156 @@
+package gapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getDashboardVersionsJSON = `
+[
+  {
+    "id": 2,
+    "dashboardId": 1,
+    "parentVersion": 1,
+    "restoredFrom": 0,
+    "version": 2,
+    "created": "2017-06-08T17:24:33-04:00",
+    "createdBy": "admin",
+    "message": "Updated panel title"
+  },
+  {
+    "id": 1,
+    "dashboardId": 1,
+    "parentVersion": 0,
+    "restoredFrom": 0,
+    "version": 1,
+    "created": "2017-06-08T17:23:33-04:00",
+    "createdBy": "admin",
+    "message": "Initial save"
+  }
+]
+`
+	getDashboardVersionJSON = `
+{
+  "id": 1,
+  "dashboardId": 1,
+  "parentVersion": 0,
+  "restoredFrom": 0,
+  "version": 1,
+  "created": "2017-04-26T17:18:38-04:00",
+  "message": "Initial save",
+  "data": {
+    "editable": false,
+    "hideControls": true,
+    "id": 1,
+    "rows": [],
+    "schemaVersion": 14,
+    "title": "test",
+    "version": 1
+  },
+  "createdBy": "admin"
+}
+`
+	restoredDashboardVersionJSON = `
+{
+  "slug": "my-dashboard",
+  "status": "success",
+  "version": 3,
+  "id": 1,
+  "uid": "QA7wKklGz",
+  "url": "/d/QA7wKklGz/my-dashboard"
+}
+`
+)
+
+func TestDashboardVersions(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getDashboardVersionsJSON)
+	defer server.Close()
+
+	versions, err := client.DashboardVersions(1, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/dashboards/id/1/versions")
+
+	t.Log(pretty.PrettyFormat(versions))
+
+	if len(versions) != 2 || versions[0].Version != 2 || versions[0].CreatedBy != "admin" || versions[0].Message != "Updated panel title" {
+		t.Error("Not correctly parsing returned dashboard versions.")
+	}
+	if versions[1].Created.Minute() != 23 {
+		t.Error("Not correctly parsing the creation time of dashboard versions.")
+	}
+}
+
+func TestDashboardVersion(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getDashboardVersionJSON)
+	defer server.Close()
+
+	version, err := client.DashboardVersion(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/dashboards/id/1/versions/1")
+
+	if version.Version != 1 || version.Data["title"] != "test" {
+		t.Error("Not correctly parsing returned dashboard version.")
+	}
+}
+
+func TestRestoreDashboardVersion(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, restoredDashboardVersionJSON)
+	defer server.Close()
+
+	resp, err := client.RestoreDashboardVersion(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("POST", "/api/dashboards/id/1/restore")
+
+	if resp.Version != 3 || resp.UID != "QA7wKklGz" {
+		t.Error("Not correctly parsing restored dashboard response.")
+	}
+}
+
+func TestCalculateDashboardDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		req := struct {
+			Base     DashboardDiffTarget `json:"base"`
+			New      DashboardDiffTarget `json:"new"`
+			DiffType string              `json:"diffType"`
+		}{}
+		if err := json.Unmarshal(data, &req); err != nil {
+			t.Error(err)
+		}
+		if req.Base.Version != 1 || req.New.Version != 2 {
+			t.Errorf("unexpected diff request %s", data)
+		}
+		fmt.Fprintf(w, `<div class="diff-%s"></div>`, req.DiffType)
+	}))
+	defer server.Close()
+
+	client, err := New("my-key", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := client.CalculateDashboardDiff(
+		DashboardDiffTarget{DashboardID: 1, Version: 1},
+		DashboardDiffTarget{DashboardID: 1, Version: 2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff.JSON != `<div class="diff-json"></div>` || diff.Basic != `<div class="diff-basic"></div>` {
+		t.Errorf("Not correctly returning the diffs: %+v", diff)
+	}
+}
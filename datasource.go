@@ -2,14 +2,18 @@ package gapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"net/url"
+	"reflect"
+	"strings"
 )
 
 type DataSource struct {
 	Id     int64  `json:"id,omitempty"`
+	Uid    string `json:"uid,omitempty"`
 	Name   string `json:"name"`
 	Type   string `json:"type"`
 	URL    string `json:"url"`
@@ -27,6 +31,11 @@ type DataSource struct {
 	BasicAuthUser     string `json:"basicAuthUser,omitempty"`
 	BasicAuthPassword string `json:"basicAuthPassword,omitempty"`
 
+	WithCredentials bool `json:"withCredentials"`
+	// Version is incremented on every update, if set an update of an
+	// outdated version is rejected
+	Version int64 `json:"version,omitempty"`
+
 	JSONData       JSONData       `json:"jsonData,omitempty"`
 	SecureJSONData SecureJSONData `json:"secureJsonData,omitempty"`
 }
@@ -61,6 +70,9 @@ type JSONData struct {
 	// General
 	KeepCookies    []string `json:"keepCookies,omitempty"`
 	ScrapeInterval string   `json:"timeInterval,omitempty"`
+
+	// Extra holds all keys without a field above, they are sent back as is
+	Extra map[string]interface{} `json:"-"`
 }
 
 // SecureJSONData is a representation of the datasource `secureJsonData` property
@@ -71,99 +83,349 @@ type SecureJSONData struct {
 
 	// General
 	Password string `json:"password,omitempty"`
+
+	// Extra holds all keys without a field above, they are sent back as is
+	Extra map[string]interface{} `json:"-"`
 }
 
-func (c *Client) NewDataSource(s *DataSource) (int64, error) {
-	data, err := json.Marshal(s)
+// MarshalJSON encodes the fields together with the Extra keys
+func (d JSONData) MarshalJSON() ([]byte, error) {
+	type jsonData JSONData
+	return marshalWithExtra(jsonData(d), d.Extra)
+}
+
+// UnmarshalJSON decodes the known keys into the fields and all others into Extra
+func (d *JSONData) UnmarshalJSON(data []byte) error {
+	type jsonData JSONData
+	known := jsonData{}
+	extra, err := unmarshalWithExtra(data, &known)
 	if err != nil {
-		return 0, err
+		return err
 	}
-	req, err := c.newRequest("POST", "/api/datasources", nil, bytes.NewBuffer(data))
+	*d = JSONData(known)
+	d.Extra = extra
+	return nil
+}
+
+// Decode decodes the jsonData into a plugin specific struct like LokiJSONData
+func (d *JSONData) Decode(v interface{}) error {
+	return decodeWithExtra(d, v)
+}
+
+// Merge sets the keys of a plugin specific struct like LokiJSONData,
+// keys not present in v are kept
+func (d *JSONData) Merge(v interface{}) error {
+	return mergeWithExtra(d, v)
+}
+
+// MarshalJSON encodes the fields together with the Extra keys
+func (d SecureJSONData) MarshalJSON() ([]byte, error) {
+	type secureJSONData SecureJSONData
+	return marshalWithExtra(secureJSONData(d), d.Extra)
+}
+
+// UnmarshalJSON decodes the known keys into the fields and all others into Extra
+func (d *SecureJSONData) UnmarshalJSON(data []byte) error {
+	type secureJSONData SecureJSONData
+	known := secureJSONData{}
+	extra, err := unmarshalWithExtra(data, &known)
 	if err != nil {
-		return 0, err
+		return err
+	}
+	*d = SecureJSONData(known)
+	d.Extra = extra
+	return nil
+}
+
+// Decode decodes the secureJsonData into a plugin specific struct like InfluxDBFluxSecureJSONData
+func (d *SecureJSONData) Decode(v interface{}) error {
+	return decodeWithExtra(d, v)
+}
+
+// Merge sets the keys of a plugin specific struct like InfluxDBFluxSecureJSONData,
+// keys not present in v are kept
+func (d *SecureJSONData) Merge(v interface{}) error {
+	return mergeWithExtra(d, v)
+}
+
+// marshalWithExtra encodes known and adds the extra keys it does not set itself
+func marshalWithExtra(known interface{}, extra map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(known)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// unmarshalWithExtra decodes data into the struct known points to and returns
+// the keys that do not belong to one of its fields
+func unmarshalWithExtra(data []byte, known interface{}) (map[string]interface{}, error) {
+	if err := json.Unmarshal(data, known); err != nil {
+		return nil, err
+	}
+	extra := map[string]interface{}{}
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf(known).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		delete(extra, name)
+	}
+	if len(extra) == 0 {
+		return nil, nil
 	}
+	return extra, nil
+}
 
-	resp, err := c.Do(req)
+func decodeWithExtra(d json.Marshaler, v interface{}) error {
+	data, err := d.MarshalJSON()
 	if err != nil {
-		return 0, err
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func mergeWithExtra(d interface {
+	json.Marshaler
+	json.Unmarshaler
+}, v interface{}) error {
+	current, err := d.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(current, &fields); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
 	}
-	if resp.StatusCode != 200 {
-		return 0, errors.New(resp.Status)
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return err
 	}
+	return d.UnmarshalJSON(merged)
+}
+
+func (c *Client) NewDataSource(s *DataSource) (int64, error) {
+	return c.NewDataSourceContext(context.Background(), s)
+}
 
-	data, err = ioutil.ReadAll(resp.Body)
+// NewDataSourceContext is like NewDataSource but takes a context for cancellation and deadlines.
+func (c *Client) NewDataSourceContext(ctx context.Context, s *DataSource) (int64, error) {
+	data, err := json.Marshal(s)
 	if err != nil {
 		return 0, err
 	}
-
 	result := struct {
 		Id int64 `json:"id"`
 	}{}
-	err = json.Unmarshal(data, &result)
+	err = c.request(ctx, "POST", "/api/datasources", nil, bytes.NewBuffer(data), &result)
 	return result.Id, err
 }
 
 func (c *Client) UpdateDataSource(s *DataSource) error {
+	return c.UpdateDataSourceContext(context.Background(), s)
+}
+
+// UpdateDataSourceContext is like UpdateDataSource but takes a context for cancellation and deadlines.
+func (c *Client) UpdateDataSourceContext(ctx context.Context, s *DataSource) error {
 	path := fmt.Sprintf("/api/datasources/%d", s.Id)
 	data, err := json.Marshal(s)
 	if err != nil {
 		return err
 	}
-	req, err := c.newRequest("PUT", path, nil, bytes.NewBuffer(data))
-	if err != nil {
-		return err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
-	}
-
-	return nil
+	return c.request(ctx, "PUT", path, nil, bytes.NewBuffer(data), nil)
 }
 
 func (c *Client) DataSource(id int64) (*DataSource, error) {
+	return c.DataSourceContext(context.Background(), id)
+}
+
+// DataSourceContext is like DataSource but takes a context for cancellation and deadlines.
+func (c *Client) DataSourceContext(ctx context.Context, id int64) (*DataSource, error) {
 	path := fmt.Sprintf("/api/datasources/%d", id)
-	req, err := c.newRequest("GET", path, nil, nil)
+	result := &DataSource{}
+	err := c.request(ctx, "GET", path, nil, nil, result)
 	if err != nil {
 		return nil, err
 	}
+	return result, err
+}
+
+func (c *Client) DeleteDataSource(id int64) error {
+	return c.DeleteDataSourceContext(context.Background(), id)
+}
+
+// DeleteDataSourceContext is like DeleteDataSource but takes a context for cancellation and deadlines.
+func (c *Client) DeleteDataSourceContext(ctx context.Context, id int64) error {
+	path := fmt.Sprintf("/api/datasources/%d", id)
+	return c.request(ctx, "DELETE", path, nil, nil, nil)
+}
 
-	resp, err := c.Do(req)
+// DataSources lists all data sources of the organization
+func (c *Client) DataSources() ([]DataSource, error) {
+	return c.DataSourcesContext(context.Background())
+}
+
+// DataSourcesContext is like DataSources but takes a context for cancellation and deadlines.
+func (c *Client) DataSourcesContext(ctx context.Context) ([]DataSource, error) {
+	dataSources := make([]DataSource, 0)
+	err := c.request(ctx, "GET", "/api/datasources", nil, nil, &dataSources)
+	return dataSources, err
+}
+
+// DataSourceByName returns the data source with the given name
+func (c *Client) DataSourceByName(name string) (*DataSource, error) {
+	return c.DataSourceByNameContext(context.Background(), name)
+}
+
+// DataSourceByNameContext is like DataSourceByName but takes a context for cancellation and deadlines.
+func (c *Client) DataSourceByNameContext(ctx context.Context, name string) (*DataSource, error) {
+	path := fmt.Sprintf("/api/datasources/name/%s", url.PathEscape(name))
+	result := &DataSource{}
+	err := c.request(ctx, "GET", path, nil, nil, result)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
-	}
+	return result, err
+}
+
+// DataSourceByUID returns the data source with the given UID
+func (c *Client) DataSourceByUID(uid string) (*DataSource, error) {
+	return c.DataSourceByUIDContext(context.Background(), uid)
+}
 
-	data, err := ioutil.ReadAll(resp.Body)
+// DataSourceByUIDContext is like DataSourceByUID but takes a context for cancellation and deadlines.
+func (c *Client) DataSourceByUIDContext(ctx context.Context, uid string) (*DataSource, error) {
+	path := fmt.Sprintf("/api/datasources/uid/%s", uid)
+	result := &DataSource{}
+	err := c.request(ctx, "GET", path, nil, nil, result)
 	if err != nil {
 		return nil, err
 	}
-
-	result := &DataSource{}
-	err = json.Unmarshal(data, &result)
 	return result, err
 }
 
-func (c *Client) DeleteDataSource(id int64) error {
-	path := fmt.Sprintf("/api/datasources/%d", id)
-	req, err := c.newRequest("DELETE", path, nil, nil)
+// UpdateDataSourceByName updates the data source with the name of s. Grafana
+// has no update by name, the id is looked up first.
+func (c *Client) UpdateDataSourceByName(s *DataSource) error {
+	return c.UpdateDataSourceByNameContext(context.Background(), s)
+}
+
+// UpdateDataSourceByNameContext is like UpdateDataSourceByName but takes a context for cancellation and deadlines.
+func (c *Client) UpdateDataSourceByNameContext(ctx context.Context, s *DataSource) error {
+	existing, err := c.DataSourceByNameContext(ctx, s.Name)
 	if err != nil {
 		return err
 	}
+	updated := *s
+	updated.Id = existing.Id
+	if updated.Uid == "" {
+		updated.Uid = existing.Uid
+	}
+	return c.UpdateDataSourceContext(ctx, &updated)
+}
 
-	resp, err := c.Do(req)
+// UpdateDataSourceByUID updates the data source with the UID of s
+func (c *Client) UpdateDataSourceByUID(s *DataSource) error {
+	return c.UpdateDataSourceByUIDContext(context.Background(), s)
+}
+
+// UpdateDataSourceByUIDContext is like UpdateDataSourceByUID but takes a context for cancellation and deadlines.
+func (c *Client) UpdateDataSourceByUIDContext(ctx context.Context, s *DataSource) error {
+	path := fmt.Sprintf("/api/datasources/uid/%s", s.Uid)
+	data, err := json.Marshal(s)
 	if err != nil {
 		return err
 	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
+	return c.request(ctx, "PUT", path, nil, bytes.NewBuffer(data), nil)
+}
+
+// DeleteDataSourceByName deletes the data source with the given name
+func (c *Client) DeleteDataSourceByName(name string) error {
+	return c.DeleteDataSourceByNameContext(context.Background(), name)
+}
+
+// DeleteDataSourceByNameContext is like DeleteDataSourceByName but takes a context for cancellation and deadlines.
+func (c *Client) DeleteDataSourceByNameContext(ctx context.Context, name string) error {
+	path := fmt.Sprintf("/api/datasources/name/%s", url.PathEscape(name))
+	return c.request(ctx, "DELETE", path, nil, nil, nil)
+}
+
+// DeleteDataSourceByUID deletes the data source with the given UID
+func (c *Client) DeleteDataSourceByUID(uid string) error {
+	return c.DeleteDataSourceByUIDContext(context.Background(), uid)
+}
+
+// DeleteDataSourceByUIDContext is like DeleteDataSourceByUID but takes a context for cancellation and deadlines.
+func (c *Client) DeleteDataSourceByUIDContext(ctx context.Context, uid string) error {
+	path := fmt.Sprintf("/api/datasources/uid/%s", uid)
+	return c.request(ctx, "DELETE", path, nil, nil, nil)
+}
+
+// DataSourceHealth is the result of a data source health check, Status is OK or ERROR
+type DataSourceHealth struct {
+	Status  string                 `json:"status"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// CheckDataSourceHealth runs the health check of the data source with the
+// given UID. A failed check is returned as *APIError with status 400 together
+// with the parsed result.
+func (c *Client) CheckDataSourceHealth(uid string) (*DataSourceHealth, error) {
+	return c.CheckDataSourceHealthContext(context.Background(), uid)
+}
+
+// CheckDataSourceHealthContext is like CheckDataSourceHealth but takes a context for cancellation and deadlines.
+func (c *Client) CheckDataSourceHealthContext(ctx context.Context, uid string) (*DataSourceHealth, error) {
+	path := fmt.Sprintf("/api/datasources/uid/%s/health", uid)
+	result := &DataSourceHealth{}
+	err := c.request(ctx, "GET", path, nil, nil, result)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 400 && json.Unmarshal(apiErr.Body, result) == nil {
+			return result, err
+		}
+		return nil, err
 	}
+	return result, err
+}
 
-	return nil
+// EnsureDataSource creates the data source if there is none with the name of
+// s and updates the existing one otherwise. It returns the id of the data source.
+func (c *Client) EnsureDataSource(s *DataSource) (int64, error) {
+	return c.EnsureDataSourceContext(context.Background(), s)
+}
+
+// EnsureDataSourceContext is like EnsureDataSource but takes a context for cancellation and deadlines.
+func (c *Client) EnsureDataSourceContext(ctx context.Context, s *DataSource) (int64, error) {
+	existing, err := c.DataSourceByNameContext(ctx, s.Name)
+	if IsNotFound(err) {
+		return c.NewDataSourceContext(ctx, s)
+	}
+	if err != nil {
+		return 0, err
+	}
+	updated := *s
+	updated.Id = existing.Id
+	if updated.Uid == "" {
+		updated.Uid = existing.Uid
+	}
+	return existing.Id, c.UpdateDataSourceContext(ctx, &updated)
 }
@@ -0,0 +1,194 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DataSourcePermissionType is the level of access granted by a DataSourcePermission
+type DataSourcePermissionType int64
+
+const (
+	DataSourcePermissionQuery DataSourcePermissionType = 1
+	DataSourcePermissionEdit  DataSourcePermissionType = 2
+)
+
+// DataSourcePermissions are the permissions of a data source, they are only
+// enforced if Enabled is set
+type DataSourcePermissions struct {
+	DatasourceID int64                  `json:"datasourceId"`
+	Enabled      bool                   `json:"enabled"`
+	Permissions  []DataSourcePermission `json:"permissions"`
+}
+
+// DataSourcePermission grants a user, team or built-in role access to a data source
+type DataSourcePermission struct {
+	ID             int64                    `json:"id"`
+	DatasourceID   int64                    `json:"datasourceId"`
+	UserID         int64                    `json:"userId,omitempty"`
+	UserLogin      string                   `json:"userLogin,omitempty"`
+	UserEmail      string                   `json:"userEmail,omitempty"`
+	TeamID         int64                    `json:"teamId,omitempty"`
+	Team           string                   `json:"team,omitempty"`
+	BuiltinRole    string                   `json:"builtinRole,omitempty"`
+	Permission     DataSourcePermissionType `json:"permission"`
+	PermissionName string                   `json:"permissionName,omitempty"`
+}
+
+// DataSourcePermissionAddPayload adds a permission, exactly one of UserID,
+// TeamID and BuiltinRole identifies who is granted the permission
+type DataSourcePermissionAddPayload struct {
+	UserID      int64                    `json:"userId,omitempty"`
+	TeamID      int64                    `json:"teamId,omitempty"`
+	BuiltinRole string                   `json:"builtinRole,omitempty"`
+	Permission  DataSourcePermissionType `json:"permission"`
+}
+
+// DataSourcePermissions returns the permissions of the data source with the given id
+func (c *Client) DataSourcePermissions(id int64) (*DataSourcePermissions, error) {
+	return c.DataSourcePermissionsContext(context.Background(), id)
+}
+
+// DataSourcePermissionsContext is like DataSourcePermissions but takes a context for cancellation and deadlines.
+func (c *Client) DataSourcePermissionsContext(ctx context.Context, id int64) (*DataSourcePermissions, error) {
+	path := fmt.Sprintf("/api/datasources/%d/permissions", id)
+	result := &DataSourcePermissions{}
+	err := c.request(ctx, "GET", path, nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// EnableDataSourcePermissions enforces the permissions of the data source with the given id
+func (c *Client) EnableDataSourcePermissions(id int64) error {
+	return c.EnableDataSourcePermissionsContext(context.Background(), id)
+}
+
+// EnableDataSourcePermissionsContext is like EnableDataSourcePermissions but takes a context for cancellation and deadlines.
+func (c *Client) EnableDataSourcePermissionsContext(ctx context.Context, id int64) error {
+	path := fmt.Sprintf("/api/datasources/%d/enable-permissions", id)
+	return c.request(ctx, "POST", path, nil, nil, nil)
+}
+
+// DisableDataSourcePermissions lets every user query the data source with the given id again
+func (c *Client) DisableDataSourcePermissions(id int64) error {
+	return c.DisableDataSourcePermissionsContext(context.Background(), id)
+}
+
+// DisableDataSourcePermissionsContext is like DisableDataSourcePermissions but takes a context for cancellation and deadlines.
+func (c *Client) DisableDataSourcePermissionsContext(ctx context.Context, id int64) error {
+	path := fmt.Sprintf("/api/datasources/%d/disable-permissions", id)
+	return c.request(ctx, "POST", path, nil, nil, nil)
+}
+
+// AddDataSourcePermission adds a permission to the data source with the given id
+func (c *Client) AddDataSourcePermission(id int64, item *DataSourcePermissionAddPayload) error {
+	return c.AddDataSourcePermissionContext(context.Background(), id, item)
+}
+
+// AddDataSourcePermissionContext is like AddDataSourcePermission but takes a context for cancellation and deadlines.
+func (c *Client) AddDataSourcePermissionContext(ctx context.Context, id int64, item *DataSourcePermissionAddPayload) error {
+	path := fmt.Sprintf("/api/datasources/%d/permissions", id)
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "POST", path, nil, bytes.NewBuffer(data), nil)
+}
+
+// RemoveDataSourcePermission removes the permission with the given permission
+// id from the data source with the given id
+func (c *Client) RemoveDataSourcePermission(id, permissionID int64) error {
+	return c.RemoveDataSourcePermissionContext(context.Background(), id, permissionID)
+}
+
+// RemoveDataSourcePermissionContext is like RemoveDataSourcePermission but takes a context for cancellation and deadlines.
+func (c *Client) RemoveDataSourcePermissionContext(ctx context.Context, id, permissionID int64) error {
+	path := fmt.Sprintf("/api/datasources/%d/permissions/%d", id, permissionID)
+	return c.request(ctx, "DELETE", path, nil, nil, nil)
+}
+
+// The permissions API only accepts data source ids, the ByUID variants look
+// up the id of the data source first.
+
+// DataSourcePermissionsByUID returns the permissions of the data source with the given UID
+func (c *Client) DataSourcePermissionsByUID(uid string) (*DataSourcePermissions, error) {
+	return c.DataSourcePermissionsByUIDContext(context.Background(), uid)
+}
+
+// DataSourcePermissionsByUIDContext is like DataSourcePermissionsByUID but takes a context for cancellation and deadlines.
+func (c *Client) DataSourcePermissionsByUIDContext(ctx context.Context, uid string) (*DataSourcePermissions, error) {
+	id, err := c.dataSourceID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return c.DataSourcePermissionsContext(ctx, id)
+}
+
+// EnableDataSourcePermissionsByUID enforces the permissions of the data source with the given UID
+func (c *Client) EnableDataSourcePermissionsByUID(uid string) error {
+	return c.EnableDataSourcePermissionsByUIDContext(context.Background(), uid)
+}
+
+// EnableDataSourcePermissionsByUIDContext is like EnableDataSourcePermissionsByUID but takes a context for cancellation and deadlines.
+func (c *Client) EnableDataSourcePermissionsByUIDContext(ctx context.Context, uid string) error {
+	id, err := c.dataSourceID(ctx, uid)
+	if err != nil {
+		return err
+	}
+	return c.EnableDataSourcePermissionsContext(ctx, id)
+}
+
+// DisableDataSourcePermissionsByUID lets every user query the data source with the given UID again
+func (c *Client) DisableDataSourcePermissionsByUID(uid string) error {
+	return c.DisableDataSourcePermissionsByUIDContext(context.Background(), uid)
+}
+
+// DisableDataSourcePermissionsByUIDContext is like DisableDataSourcePermissionsByUID but takes a context for cancellation and deadlines.
+func (c *Client) DisableDataSourcePermissionsByUIDContext(ctx context.Context, uid string) error {
+	id, err := c.dataSourceID(ctx, uid)
+	if err != nil {
+		return err
+	}
+	return c.DisableDataSourcePermissionsContext(ctx, id)
+}
+
+// AddDataSourcePermissionByUID adds a permission to the data source with the given UID
+func (c *Client) AddDataSourcePermissionByUID(uid string, item *DataSourcePermissionAddPayload) error {
+	return c.AddDataSourcePermissionByUIDContext(context.Background(), uid, item)
+}
+
+// AddDataSourcePermissionByUIDContext is like AddDataSourcePermissionByUID but takes a context for cancellation and deadlines.
+func (c *Client) AddDataSourcePermissionByUIDContext(ctx context.Context, uid string, item *DataSourcePermissionAddPayload) error {
+	id, err := c.dataSourceID(ctx, uid)
+	if err != nil {
+		return err
+	}
+	return c.AddDataSourcePermissionContext(ctx, id, item)
+}
+
+// RemoveDataSourcePermissionByUID removes the permission with the given
+// permission id from the data source with the given UID
+func (c *Client) RemoveDataSourcePermissionByUID(uid string, permissionID int64) error {
+	return c.RemoveDataSourcePermissionByUIDContext(context.Background(), uid, permissionID)
+}
+
+// RemoveDataSourcePermissionByUIDContext is like RemoveDataSourcePermissionByUID but takes a context for cancellation and deadlines.
+func (c *Client) RemoveDataSourcePermissionByUIDContext(ctx context.Context, uid string, permissionID int64) error {
+	id, err := c.dataSourceID(ctx, uid)
+	if err != nil {
+		return err
+	}
+	return c.RemoveDataSourcePermissionContext(ctx, id, permissionID)
+}
+
+func (c *Client) dataSourceID(ctx context.Context, uid string) (int64, error) {
+	ds, err := c.DataSourceByUIDContext(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	return ds.Id, nil
+}
@@ -0,0 +1,111 @@
+package gapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getDataSourcePermissionsJSON = `
+{
+  "datasourceId": 3,
+  "enabled": true,
+  "permissions": [
+    {
+      "id": 1,
+      "datasourceId": 3,
+      "userId": 1,
+      "userLogin": "admin",
+      "userEmail": "admin@localhost",
+      "userAvatarUrl": "/avatar/46d229b033af06a191ff2267bca9ae56",
+      "permission": 1,
+      "permissionName": "Query",
+      "created": "2017-06-20T02:00:00+02:00",
+      "updated": "2017-06-20T02:00:00+02:00"
+    },
+    {
+      "id": 2,
+      "datasourceId": 3,
+      "teamId": 1,
+      "team": "A Team",
+      "teamAvatarUrl": "/avatar/46d229b033af06a191ff2267bca9ae56",
+      "permission": 1,
+      "permissionName": "Query",
+      "created": "2017-06-20T02:00:00+02:00",
+      "updated": "2017-06-20T02:00:00+02:00"
+    }
+  ]
+}
+`
+)
+
+func TestDataSourcePermissions(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getDataSourcePermissionsJSON)
+	defer server.Close()
+
+	permissions, err := client.DataSourcePermissions(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/datasources/3/permissions")
+
+	t.Log(pretty.PrettyFormat(permissions))
+
+	if !permissions.Enabled || len(permissions.Permissions) != 2 {
+		t.Fatal("Not correctly parsing returned datasource permissions.")
+	}
+	if p := permissions.Permissions[1]; p.TeamID != 1 || p.Team != "A Team" || p.Permission != DataSourcePermissionQuery {
+		t.Error("Not correctly parsing returned datasource permission.")
+	}
+}
+
+func TestEnableDataSourcePermissions(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"Datasource permissions enabled"}`)
+	defer server.Close()
+
+	if err := client.EnableDataSourcePermissions(3); err != nil {
+		t.Error(err)
+	}
+	rec.expect("POST", "/api/datasources/3/enable-permissions")
+	if err := client.DisableDataSourcePermissions(3); err != nil {
+		t.Error(err)
+	}
+	rec.expect("POST", "/api/datasources/3/disable-permissions")
+}
+
+func TestRemoveDataSourcePermission(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"Datasource permission removed"}`)
+	defer server.Close()
+
+	if err := client.RemoveDataSourcePermission(3, 2); err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/datasources/3/permissions/2")
+}
+
+func TestAddDataSourcePermissionByUID(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"Datasource permission added"}`)
+	defer server.Close()
+	rec.respond("GET", "", 200, getDataSourceJSON)
+
+	err := client.AddDataSourcePermissionByUID("P1809F7CD0C75ACF3", &DataSourcePermissionAddPayload{
+		BuiltinRole: "Viewer",
+		Permission:  DataSourcePermissionQuery,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests := rec.all(); len(requests) != 2 || requests[0].Path != "/api/datasources/uid/P1809F7CD0C75ACF3" {
+		t.Errorf("expected the id to be looked up by UID, got requests %v", requests)
+	}
+	var added DataSourcePermissionAddPayload
+	if err := json.Unmarshal([]byte(rec.expect("POST", "/api/datasources/3/permissions").Body), &added); err != nil {
+		t.Fatal(err)
+	}
+	if added.BuiltinRole != "Viewer" || added.Permission != DataSourcePermissionQuery {
+		t.Errorf("unexpected permission payload %+v", added)
+	}
+}
@@ -0,0 +1,128 @@
+package gapi
+
+// The structs below hold the jsonData and secureJsonData options of single
+// data source plugins. Use JSONData.Decode to read them and JSONData.Merge to
+// set them, keys of other plugins and unknown keys are kept either way.
+
+// LokiJSONData are the jsonData options of the loki data source
+type LokiJSONData struct {
+	MaxLines      int64              `json:"maxLines,omitempty"`
+	DerivedFields []LokiDerivedField `json:"derivedFields,omitempty"`
+}
+
+// LokiDerivedField extracts a value from a log line and links it to a URL
+// or, if DatasourceUID is set, to a query of that data source
+type LokiDerivedField struct {
+	Name            string `json:"name"`
+	MatcherRegex    string `json:"matcherRegex"`
+	URL             string `json:"url"`
+	URLDisplayLabel string `json:"urlDisplayLabel,omitempty"`
+	DatasourceUID   string `json:"datasourceUid,omitempty"`
+}
+
+// TracingJSONData are the jsonData options shared by the tempo, jaeger and
+// zipkin data sources
+type TracingJSONData struct {
+	TracesToLogs *TracesToLogs `json:"tracesToLogs,omitempty"`
+	NodeGraph    *NodeGraph    `json:"nodeGraph,omitempty"`
+}
+
+// TracesToLogs links the spans of a trace to the logs of a loki data source
+type TracesToLogs struct {
+	DatasourceUID      string   `json:"datasourceUid"`
+	Tags               []string `json:"tags,omitempty"`
+	SpanStartTimeShift string   `json:"spanStartTimeShift,omitempty"`
+	SpanEndTimeShift   string   `json:"spanEndTimeShift,omitempty"`
+	FilterByTraceID    bool     `json:"filterByTraceID"`
+	FilterBySpanID     bool     `json:"filterBySpanID"`
+}
+
+// NodeGraph enables the node graph visualization of traces
+type NodeGraph struct {
+	Enabled bool `json:"enabled"`
+}
+
+// TempoJSONData are the jsonData options of the tempo data source
+type TempoJSONData struct {
+	TracingJSONData
+	ServiceMap *TempoServiceMap `json:"serviceMap,omitempty"`
+	Search     *TempoSearch     `json:"search,omitempty"`
+}
+
+// TempoServiceMap reads the service graph metrics from a prometheus data source
+type TempoServiceMap struct {
+	DatasourceUID string `json:"datasourceUid"`
+}
+
+// TempoSearch configures the trace search of tempo
+type TempoSearch struct {
+	Hide bool `json:"hide"`
+}
+
+// JaegerJSONData are the jsonData options of the jaeger data source
+type JaegerJSONData struct {
+	TracingJSONData
+}
+
+// ZipkinJSONData are the jsonData options of the zipkin data source
+type ZipkinJSONData struct {
+	TracingJSONData
+}
+
+// InfluxDBFluxJSONData are the jsonData options of an influxdb data source
+// using the Flux query language
+type InfluxDBFluxJSONData struct {
+	// Version is Flux for the Flux query language
+	Version       string `json:"version"`
+	Organization  string `json:"organization"`
+	DefaultBucket string `json:"defaultBucket"`
+	MaxSeries     int64  `json:"maxSeries,omitempty"`
+}
+
+// InfluxDBFluxSecureJSONData are the secureJsonData options of an influxdb
+// data source using the Flux query language
+type InfluxDBFluxSecureJSONData struct {
+	Token string `json:"token"`
+}
+
+// SQLConnectionJSONData are the connection pool options of the sql data sources
+type SQLConnectionJSONData struct {
+	MaxOpenConns    int64 `json:"maxOpenConns,omitempty"`
+	MaxIdleConns    int64 `json:"maxIdleConns,omitempty"`
+	ConnMaxLifetime int64 `json:"connMaxLifetime,omitempty"`
+}
+
+// MySQLJSONData are the jsonData options of the mysql data source,
+// the certificates are set in TLSSecureJSONData
+type MySQLJSONData struct {
+	SQLConnectionJSONData
+	TLSAuth           bool   `json:"tlsAuth"`
+	TLSAuthWithCACert bool   `json:"tlsAuthWithCACert"`
+	TLSSkipVerify     bool   `json:"tlsSkipVerify"`
+	Timezone          string `json:"timezone,omitempty"`
+}
+
+// MSSQLJSONData are the jsonData options of the mssql data source
+type MSSQLJSONData struct {
+	SQLConnectionJSONData
+	// Encrypt is one of true, false or disable
+	Encrypt            string `json:"encrypt,omitempty"`
+	TLSSkipVerify      bool   `json:"tlsSkipVerify"`
+	ServerName         string `json:"serverName,omitempty"`
+	SSLRootCertFile    string `json:"sslRootCertFile,omitempty"`
+	AuthenticationType string `json:"authenticationType,omitempty"`
+}
+
+// TLSSecureJSONData are the secureJsonData options of data sources with TLS client authentication
+type TLSSecureJSONData struct {
+	TLSCACert     string `json:"tlsCACert,omitempty"`
+	TLSClientCert string `json:"tlsClientCert,omitempty"`
+	TLSClientKey  string `json:"tlsClientKey,omitempty"`
+}
+
+// AlertmanagerJSONData are the jsonData options of the alertmanager data source
+type AlertmanagerJSONData struct {
+	// Implementation is one of prometheus, cortex or mimir
+	Implementation             string `json:"implementation"`
+	HandleGrafanaManagedAlerts bool   `json:"handleGrafanaManagedAlerts"`
+}
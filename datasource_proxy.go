@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// dataSourceProxyProtectedHeaders are set by the Client and cannot be passed
+// to DataSourceProxyWithHeader
+var dataSourceProxyProtectedHeaders = []string{"Authorization", "Cookie", "X-Grafana-Org-Id"}
+
+// DataSourceProxy sends a request to the native API of the data source with
+// the given UID through the Grafana data source proxy, e.g. path api/v1/query
+// of a Prometheus data source. The request reuses the auth and org headers of
+// the Client. The response is returned whatever its status code, the caller
+// has to close its body.
+func (c *Client) DataSourceProxy(uid, method, path string, query url.Values, body io.Reader) (*http.Response, error) {
+	return c.DataSourceProxyContext(context.Background(), uid, method, path, query, body)
+}
+
+// DataSourceProxyContext is like DataSourceProxy but takes a context for cancellation and deadlines.
+func (c *Client) DataSourceProxyContext(ctx context.Context, uid, method, path string, query url.Values, body io.Reader) (*http.Response, error) {
+	return c.send(ctx, method, dataSourceProxyPath(uid, path), query, nil, body)
+}
+
+// DataSourceProxyWithHeader is like DataSourceProxy but adds header to the
+// request, its Content-Type replaces the application/json default, e.g. for an
+// Elasticsearch _msearch sending application/x-ndjson. Authorization, Cookie and
+// X-Grafana-Org-Id are rejected, the Client headers are kept.
+func (c *Client) DataSourceProxyWithHeader(uid, method, path string, query url.Values, header http.Header, body io.Reader) (*http.Response, error) {
+	return c.DataSourceProxyWithHeaderContext(context.Background(), uid, method, path, query, header, body)
+}
+
+// DataSourceProxyWithHeaderContext is like DataSourceProxyWithHeader but takes a context for cancellation and deadlines.
+func (c *Client) DataSourceProxyWithHeaderContext(ctx context.Context, uid, method, path string, query url.Values, header http.Header, body io.Reader) (*http.Response, error) {
+	for key := range header {
+		for _, protected := range dataSourceProxyProtectedHeaders {
+			if http.CanonicalHeaderKey(key) == protected {
+				return nil, fmt.Errorf("header %s cannot be set on a data source proxy request", protected)
+			}
+		}
+	}
+	return c.send(ctx, method, dataSourceProxyPath(uid, path), query, header, body)
+}
+
+func dataSourceProxyPath(uid, path string) string {
+	return fmt.Sprintf("/api/datasources/proxy/uid/%s/%s", uid, strings.TrimPrefix(path, "/"))
+}
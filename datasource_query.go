@@ -0,0 +1,177 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+)
+
+// QueryRequest runs the queries over the time range, From and To accept the
+// relative Grafana syntax like now-1h as well as epoch milliseconds
+type QueryRequest struct {
+	TimeRange
+	Queries []DataSourceQuery `json:"queries"`
+}
+
+// DataSourceQuery is a single query of a QueryRequest
+type DataSourceQuery struct {
+	RefID         string        `json:"refId"`
+	Datasource    DataSourceRef `json:"datasource"`
+	Expr          string        `json:"expr,omitempty"`
+	QueryType     string        `json:"queryType,omitempty"`
+	IntervalMs    int64         `json:"intervalMs,omitempty"`
+	MaxDataPoints int64         `json:"maxDataPoints,omitempty"`
+	Hide          bool          `json:"hide,omitempty"`
+
+	// Extra holds plugin specific query fields like rawSql or format
+	Extra map[string]interface{} `json:"-"`
+}
+
+// DataSourceRef references a data source by UID
+type DataSourceRef struct {
+	Type string `json:"type,omitempty"`
+	UID  string `json:"uid"`
+}
+
+// MarshalJSON encodes the fields together with the Extra keys
+func (q DataSourceQuery) MarshalJSON() ([]byte, error) {
+	type dataSourceQuery DataSourceQuery
+	return marshalWithExtra(dataSourceQuery(q), q.Extra)
+}
+
+// QueryResponse holds the result of every query by its RefID
+type QueryResponse struct {
+	Results map[string]QueryResult `json:"results"`
+}
+
+// QueryResult is the result of a single query, Error is set if it failed
+type QueryResult struct {
+	Status int         `json:"status,omitempty"`
+	Error  string      `json:"error,omitempty"`
+	Frames []DataFrame `json:"frames"`
+}
+
+// DataFrame is a table of columns described by Schema.Fields,
+// use Columns or Rows to read the values
+type DataFrame struct {
+	Schema DataFrameSchema `json:"schema"`
+	Data   DataFrameData   `json:"data"`
+}
+
+// DataFrameSchema describes the fields of a data frame
+type DataFrameSchema struct {
+	Name   string                 `json:"name,omitempty"`
+	RefID  string                 `json:"refId,omitempty"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+	Fields []DataFrameField       `json:"fields"`
+}
+
+// DataFrameField is a column of a data frame, Type is e.g. time, number or string
+type DataFrameField struct {
+	Name   string                 `json:"name"`
+	Type   string                 `json:"type"`
+	Labels map[string]string      `json:"labels,omitempty"`
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// DataFrameData holds the values of a data frame by column, values that
+// cannot be encoded in JSON like NaN are listed in Entities
+type DataFrameData struct {
+	Values   [][]interface{}      `json:"values"`
+	Entities []*DataFrameEntities `json:"entities,omitempty"`
+}
+
+// DataFrameEntities lists the indexes of the special float values of a column
+type DataFrameEntities struct {
+	NaN    []int `json:"NaN,omitempty"`
+	Inf    []int `json:"Inf,omitempty"`
+	NegInf []int `json:"NegInf,omitempty"`
+}
+
+// DataFrameColumn is a field of a data frame with its decoded values
+type DataFrameColumn struct {
+	Field DataFrameField
+	// Values are time.Time for time fields, float64 for numbers, string or
+	// bool for the other types and nil for null values
+	Values []interface{}
+}
+
+// Columns returns the fields of the frame with their values, epoch
+// milliseconds of time fields are converted to time.Time
+func (f DataFrame) Columns() []DataFrameColumn {
+	columns := make([]DataFrameColumn, len(f.Schema.Fields))
+	for i, field := range f.Schema.Fields {
+		columns[i].Field = field
+		if i >= len(f.Data.Values) {
+			continue
+		}
+		values := make([]interface{}, len(f.Data.Values[i]))
+		for j, value := range f.Data.Values[i] {
+			if ms, ok := value.(float64); ok && field.Type == "time" {
+				value = time.Unix(0, int64(ms*float64(time.Millisecond)))
+			}
+			values[j] = value
+		}
+		if i < len(f.Data.Entities) && f.Data.Entities[i] != nil {
+			setEntities(values, f.Data.Entities[i].NaN, math.NaN())
+			setEntities(values, f.Data.Entities[i].Inf, math.Inf(1))
+			setEntities(values, f.Data.Entities[i].NegInf, math.Inf(-1))
+		}
+		columns[i].Values = values
+	}
+	return columns
+}
+
+func setEntities(values []interface{}, indexes []int, value float64) {
+	for _, i := range indexes {
+		if i < len(values) {
+			values[i] = value
+		}
+	}
+}
+
+// Rows returns the values of the frame by row in the order of the fields
+func (f DataFrame) Rows() [][]interface{} {
+	columns := f.Columns()
+	if len(columns) == 0 {
+		return nil
+	}
+	rows := make([][]interface{}, len(columns[0].Values))
+	for i := range rows {
+		rows[i] = make([]interface{}, len(columns))
+		for j, column := range columns {
+			if i < len(column.Values) {
+				rows[i][j] = column.Values[i]
+			}
+		}
+	}
+	return rows
+}
+
+// QueryDataSources runs the queries of the request against their data sources.
+// Grafana answers with 400 if a single query fails, the response holding the
+// results of all queries is then returned together with the *APIError.
+func (c *Client) QueryDataSources(query QueryRequest) (*QueryResponse, error) {
+	return c.QueryDataSourcesContext(context.Background(), query)
+}
+
+// QueryDataSourcesContext is like QueryDataSources but takes a context for cancellation and deadlines.
+func (c *Client) QueryDataSourcesContext(ctx context.Context, query QueryRequest) (*QueryResponse, error) {
+	data, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	result := &QueryResponse{}
+	err = c.request(ctx, "POST", "/api/ds/query", nil, bytes.NewBuffer(data), result)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 400 && json.Unmarshal(apiErr.Body, result) == nil && len(result.Results) > 0 {
+			return result, err
+		}
+		return nil, err
+	}
+	return result, err
+}
@@ -0,0 +1,153 @@
+package gapi
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	queryDataSourcesJSON = `
+{
+  "results": {
+    "A": {
+      "status": 200,
+      "frames": [
+        {
+          "schema": {
+            "refId": "A",
+            "meta": {"executedQueryString": "Expr: up"},
+            "fields": [
+              {"name": "Time", "type": "time", "typeInfo": {"frame": "time.Time"}, "config": {"interval": 15000}},
+              {"name": "Value", "type": "number", "typeInfo": {"frame": "float64"}, "labels": {"__name__": "up", "job": "grafana"}, "config": {}}
+            ]
+          },
+          "data": {
+            "values": [
+              [1660000000000, 1660000015000, 1660000030000],
+              [1, null, null]
+            ],
+            "entities": [null, {"NaN": [2]}]
+          }
+        }
+      ]
+    },
+    "B": {
+      "status": 400,
+      "error": "bad_data: parse error",
+      "frames": []
+    }
+  }
+}
+`
+)
+
+func TestQueryDataSources(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, queryDataSourcesJSON)
+	defer server.Close()
+
+	resp, err := client.QueryDataSources(QueryRequest{
+		TimeRange: TimeRange{From: "now-1h", To: "now"},
+		Queries: []DataSourceQuery{
+			{RefID: "A", Datasource: DataSourceRef{UID: "prometheus"}, Expr: "up"},
+			{RefID: "B", Datasource: DataSourceRef{UID: "mysql"}, Extra: map[string]interface{}{"rawSql": "SELECT 1", "format": "table"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(pretty.PrettyFormat(resp))
+
+	var request map[string]interface{}
+	if err := json.Unmarshal([]byte(rec.expect("POST", "/api/ds/query").Body), &request); err != nil {
+		t.Fatal(err)
+	}
+	if request["from"] != "now-1h" || request["to"] != "now" {
+		t.Errorf("expected the time range at the top level of the request, got %v", request)
+	}
+	queries := request["queries"].([]interface{})
+	if sql := queries[1].(map[string]interface{}); sql["rawSql"] != "SELECT 1" || sql["expr"] != nil {
+		t.Errorf("expected the extra query fields to be sent, got %v", sql)
+	}
+
+	if resp.Results["B"].Error != "bad_data: parse error" || len(resp.Results["A"].Frames) != 1 {
+		t.Fatal("Not correctly parsing returned query results.")
+	}
+	frame := resp.Results["A"].Frames[0]
+	columns := frame.Columns()
+	if len(columns) != 2 || columns[1].Field.Labels["job"] != "grafana" {
+		t.Fatal("Not correctly parsing the fields of the data frame.")
+	}
+	if ts, ok := columns[0].Values[1].(time.Time); !ok || !ts.Equal(time.Unix(1660000015, 0)) {
+		t.Errorf("expected time values to be converted, got %v", columns[0].Values[1])
+	}
+
+	rows := frame.Rows()
+	if len(rows) != 3 || rows[0][1] != float64(1) || rows[1][1] != nil {
+		t.Errorf("Not correctly converting the data frame into rows: %v", rows)
+	}
+	if v, ok := rows[2][1].(float64); !ok || !math.IsNaN(v) {
+		t.Errorf("expected the NaN entity to be restored, got %v", rows[2][1])
+	}
+}
+
+func TestQueryDataSourcesPartialFailure(t *testing.T) {
+	for _, code := range []int{400, 207} {
+		server, client, rec := gapiRecorderTestTools(t, code, queryDataSourcesJSON)
+
+		resp, err := client.QueryDataSources(QueryRequest{Queries: []DataSourceQuery{{RefID: "A"}, {RefID: "B"}}})
+		if code == 400 && !IsBadRequest(err) {
+			t.Errorf("expected a bad request error, got %v", err)
+		}
+		if code == 207 && err != nil {
+			t.Errorf("expected a multi-status response to succeed, got %v", err)
+		}
+		if resp == nil || len(resp.Results["A"].Frames) != 1 || resp.Results["B"].Error != "bad_data: parse error" {
+			t.Errorf("expected the results of all queries to be returned with status %d", code)
+		}
+		rec.expect("POST", "/api/ds/query")
+
+		server.Close()
+	}
+
+	server, client, rec := gapiRecorderTestTools(t, 400, `{"message":"bad request data"}`)
+	defer server.Close()
+
+	if resp, err := client.QueryDataSources(QueryRequest{}); resp != nil || !IsBadRequest(err) {
+		t.Errorf("expected no response for a rejected request, got %v, %v", resp, err)
+	}
+	rec.expect("POST", "/api/ds/query")
+}
+
+func TestCheckDataSourceHealth(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"status":"OK","message":"Data source is working"}`)
+	defer server.Close()
+
+	health, err := client.CheckDataSourceHealth("P1809F7CD0C75ACF3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/datasources/uid/P1809F7CD0C75ACF3/health")
+
+	if health.Status != "OK" || health.Message != "Data source is working" {
+		t.Error("Not correctly parsing returned health check.")
+	}
+}
+
+func TestCheckDataSourceHealthFailed(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 400, `{"status":"ERROR","message":"connection refused"}`)
+	defer server.Close()
+
+	health, err := client.CheckDataSourceHealth("P1809F7CD0C75ACF3")
+	if !IsBadRequest(err) {
+		t.Errorf("expected a bad request error, got %v", err)
+	}
+	rec.expect("GET", "/api/datasources/uid/P1809F7CD0C75ACF3/health")
+	if health == nil || health.Status != "ERROR" || health.Message != "connection refused" {
+		t.Error("expected the failed health check to be returned with the error")
+	}
+}
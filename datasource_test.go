@@ -1,10 +1,14 @@
 package gapi
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/gobs/pretty"
@@ -12,6 +16,25 @@ import (
 
 const (
 	createdDataSourceJSON = `{"id":1,"message":"Datasource added", "name": "test_datasource"}`
+
+	getDataSourceJSON = `
+{
+  "id": 3,
+  "uid": "P1809F7CD0C75ACF3",
+  "orgId": 1,
+  "name": "prometheus",
+  "type": "prometheus",
+  "access": "proxy",
+  "url": "http://prometheus:9090",
+  "basicAuth": false,
+  "withCredentials": true,
+  "isDefault": true,
+  "jsonData": {"timeInterval": "30s"},
+  "version": 4,
+  "readOnly": false
+}
+`
+	getDataSourcesJSON = `[` + getDataSourceJSON + `]`
 )
 
 func gapiTestTools(code int, body string) (*httptest.Server, *Client) {
@@ -34,11 +57,108 @@ func gapiTestTools(code int, body string) (*httptest.Server, *Client) {
 		Host:   "my-grafana.com",
 	}
 
-	client := &Client{"my-key", url, httpClient}
+	client, _ := NewClient(url.String(), WithAPIKey("my-key"), WithHTTPClient(httpClient))
 
 	return server, client
 }
 
+// testRequest is a request received by a gapiRecorderTestTools server
+type testRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   string
+}
+
+type testResponse struct {
+	code int
+	body string
+}
+
+// testRecorder keeps the requests received by a gapiRecorderTestTools server
+type testRecorder struct {
+	t         *testing.T
+	mu        sync.Mutex
+	requests  []testRequest
+	responses map[string]testResponse
+}
+
+// gapiRecorderTestTools returns a server that answers every request with code
+// and body, unless another response is set with respond, and records the
+// method, escaped path, query, headers and body of every request
+func gapiRecorderTestTools(t *testing.T, code int, body string) (*httptest.Server, *Client, *testRecorder) {
+	rec := &testRecorder{t: t, responses: map[string]testResponse{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, testRequest{
+			Method: r.Method,
+			Path:   r.URL.EscapedPath(),
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+			Body:   string(data),
+		})
+		resp, ok := rec.responses[r.Method+" "+r.URL.EscapedPath()]
+		if !ok {
+			resp, ok = rec.responses[r.Method]
+		}
+		rec.mu.Unlock()
+		if !ok {
+			resp = testResponse{code, body}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.code)
+		fmt.Fprint(w, resp.body)
+	}))
+
+	client, err := New("my-key", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, client, rec
+}
+
+// respond answers requests with method and path with code and body, an empty
+// path matches every request with method
+func (rec *testRecorder) respond(method, path string, code int, body string) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	key := method
+	if path != "" {
+		key += " " + path
+	}
+	rec.responses[key] = testResponse{code, body}
+}
+
+// all returns the recorded requests in the order they were received
+func (rec *testRecorder) all() []testRequest {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append([]testRequest(nil), rec.requests...)
+}
+
+// last returns the last recorded request, the test fails if there is none
+func (rec *testRecorder) last() testRequest {
+	rec.t.Helper()
+	requests := rec.all()
+	if len(requests) == 0 {
+		rec.t.Fatal("expected a request to be sent")
+	}
+	return requests[len(requests)-1]
+}
+
+// expect fails the test unless the last request was sent with method to path
+func (rec *testRecorder) expect(method, path string) testRequest {
+	rec.t.Helper()
+	req := rec.last()
+	if req.Method != method || req.Path != path {
+		rec.t.Errorf("expected %s %s, got %s %s", method, path, req.Method, req.Path)
+	}
+	return req
+}
+
 func TestNewDataSource(t *testing.T) {
 	server, client := gapiTestTools(200, createdDataSourceJSON)
 	defer server.Close()
@@ -76,3 +196,180 @@ func TestNewDataSource(t *testing.T) {
 		t.Error("datasource creation response should return the created datasource ID")
 	}
 }
+
+func TestDataSources(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getDataSourcesJSON)
+	defer server.Close()
+
+	dataSources, err := client.DataSources()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/datasources")
+
+	t.Log(pretty.PrettyFormat(dataSources))
+
+	if len(dataSources) != 1 || dataSources[0].Uid != "P1809F7CD0C75ACF3" || dataSources[0].JSONData.ScrapeInterval != "30s" {
+		t.Error("Not correctly parsing returned datasources.")
+	}
+}
+
+func TestDataSourceByUID(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getDataSourceJSON)
+	defer server.Close()
+
+	ds, err := client.DataSourceByUID("P1809F7CD0C75ACF3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/datasources/uid/P1809F7CD0C75ACF3")
+
+	if ds.Id != 3 || ds.Name != "prometheus" || !ds.WithCredentials || ds.Version != 4 {
+		t.Error("Not correctly parsing returned datasource.")
+	}
+}
+
+func TestDeleteDataSourceByName(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"id":3,"message":"Data source deleted"}`)
+	defer server.Close()
+
+	err := client.DeleteDataSourceByName("prometheus")
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/datasources/name/prometheus")
+
+	if err := client.DeleteDataSourceByName("team/a?b%"); err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/datasources/name/team%2Fa%3Fb%25")
+}
+
+func TestUpdateDataSourceByName(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"id":3,"message":"Datasource updated"}`)
+	defer server.Close()
+	rec.respond("GET", "", 200, getDataSourceJSON)
+
+	err := client.UpdateDataSourceByName(&DataSource{Name: "prometheus", Type: "prometheus", URL: "http://prometheus:9091"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests := rec.all(); len(requests) != 2 || requests[0].Path != "/api/datasources/name/prometheus" {
+		t.Errorf("expected the id to be looked up by name, got requests %v", requests)
+	}
+	updated := DataSource{}
+	if err := json.Unmarshal([]byte(rec.expect("PUT", "/api/datasources/3").Body), &updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Id != 3 || updated.Uid != "P1809F7CD0C75ACF3" || updated.URL != "http://prometheus:9091" {
+		t.Errorf("expected the id and UID of the existing datasource to be sent, got %s", pretty.PrettyFormat(updated))
+	}
+}
+
+func TestEnsureDataSourceCreates(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"id":7,"message":"Datasource added"}`)
+	defer server.Close()
+	rec.respond("GET", "/api/datasources/name/prometheus", 404, `{"message":"Data source not found"}`)
+
+	id, err := client.EnsureDataSource(&DataSource{Name: "prometheus", Type: "prometheus"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != 7 || len(rec.all()) != 2 {
+		t.Errorf("expected the missing datasource to be created, got id %d and requests %v", id, rec.all())
+	}
+	rec.expect("POST", "/api/datasources")
+}
+
+func TestEnsureDataSourceUpdates(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"id":7,"message":"Datasource updated"}`)
+	defer server.Close()
+	rec.respond("GET", "/api/datasources/name/prometheus", 200, getDataSourceJSON)
+
+	ds := &DataSource{Name: "prometheus", Type: "prometheus", URL: "http://prometheus:9091"}
+	id, err := client.EnsureDataSource(ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != 3 || len(rec.all()) != 2 {
+		t.Errorf("expected the existing datasource to be updated, got id %d and requests %v", id, rec.all())
+	}
+	rec.expect("PUT", "/api/datasources/3")
+	if ds.Id != 0 {
+		t.Error("expected the passed datasource not to be modified")
+	}
+}
+
+func TestJSONDataKeepsUnknownKeys(t *testing.T) {
+	input := `{"timeInterval":"30s","httpHeaderName1":"X-Scope-OrgID","tlsAuth":true,"derivedFields":[{"name":"traceID","matcherRegex":"traceID=(\\w+)","url":"${__value.raw}","datasourceUid":"tempo"}]}`
+
+	ds := DataSource{}
+	if err := json.Unmarshal([]byte(`{"name":"loki","jsonData":`+input+`,"secureJsonData":{"password":"secret","httpHeaderValue1":"1"}}`), &ds); err != nil {
+		t.Fatal(err)
+	}
+
+	if ds.JSONData.ScrapeInterval != "30s" || ds.JSONData.Extra["httpHeaderName1"] != "X-Scope-OrgID" || ds.JSONData.Extra["timeInterval"] != nil {
+		t.Errorf("Not correctly splitting known and unknown jsonData keys: %s", pretty.PrettyFormat(ds.JSONData))
+	}
+	if ds.SecureJSONData.Password != "secret" || ds.SecureJSONData.Extra["httpHeaderValue1"] != "1" {
+		t.Errorf("Not correctly splitting known and unknown secureJsonData keys: %s", pretty.PrettyFormat(ds.SecureJSONData))
+	}
+
+	data, err := json.Marshal(ds.JSONData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected, actual map[string]interface{}
+	_ = json.Unmarshal([]byte(input), &expected)
+	_ = json.Unmarshal(data, &actual)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected jsonData to survive a round trip, got %s", data)
+	}
+}
+
+func TestJSONDataPlugins(t *testing.T) {
+	d := JSONData{ScrapeInterval: "30s", Extra: map[string]interface{}{"maxLines": 500, "httpHeaderName1": "X-Scope-OrgID"}}
+
+	loki := LokiJSONData{}
+	if err := d.Decode(&loki); err != nil {
+		t.Fatal(err)
+	}
+	if loki.MaxLines != 500 {
+		t.Error("Not correctly decoding the loki jsonData.")
+	}
+
+	loki.DerivedFields = append(loki.DerivedFields, LokiDerivedField{Name: "traceID", MatcherRegex: "traceID=(\\w+)", DatasourceUID: "tempo"})
+	if err := d.Merge(loki); err != nil {
+		t.Fatal(err)
+	}
+	if d.ScrapeInterval != "30s" || d.Extra["httpHeaderName1"] != "X-Scope-OrgID" || d.Extra["maxLines"] != float64(500) {
+		t.Errorf("expected the other keys to be kept, got %s", pretty.PrettyFormat(d))
+	}
+	if fields, ok := d.Extra["derivedFields"].([]interface{}); !ok || len(fields) != 1 {
+		t.Errorf("expected the derived fields to be merged, got %s", pretty.PrettyFormat(d))
+	}
+
+	tempo := TempoJSONData{ServiceMap: &TempoServiceMap{DatasourceUID: "prometheus"}}
+	tempo.TracesToLogs = &TracesToLogs{DatasourceUID: "loki", FilterByTraceID: true}
+	if err := d.Merge(tempo); err != nil {
+		t.Fatal(err)
+	}
+	decoded := TempoJSONData{}
+	if err := d.Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.TracesToLogs == nil || !decoded.TracesToLogs.FilterByTraceID || decoded.ServiceMap.DatasourceUID != "prometheus" {
+		t.Errorf("Not correctly decoding the tempo jsonData: %s", pretty.PrettyFormat(decoded))
+	}
+
+	secure := SecureJSONData{}
+	if err := secure.Merge(InfluxDBFluxSecureJSONData{Token: "token"}); err != nil {
+		t.Fatal(err)
+	}
+	if secure.Extra["token"] != "token" {
+		t.Error("Not correctly merging the influxdb secureJsonData.")
+	}
+}
@@ -0,0 +1,84 @@
+package gapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned for every Grafana response with a non 2xx status code
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Message is the `message` field of the Grafana error response, if any
+	Message string
+	// Body is the raw response body
+	Body []byte
+	// Method and Path identify the failed request
+	Method string
+	Path   string
+}
+
+func newAPIError(method, path string, statusCode int, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		Body:       body,
+		Method:     method,
+		Path:       path,
+	}
+	msg := struct {
+		Message string `json:"message"`
+	}{}
+	if json.Unmarshal(body, &msg) == nil {
+		e.Message = msg.Message
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	detail := e.Message
+	if detail == "" {
+		detail = string(e.Body)
+	}
+	if detail == "" {
+		detail = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("%s %s: status: %d, message: %s", e.Method, e.Path, e.StatusCode, detail)
+}
+
+func hasStatusCode(err error, code int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == code
+}
+
+// IsBadRequest reports whether err is an APIError with status 400
+func IsBadRequest(err error) bool {
+	return hasStatusCode(err, http.StatusBadRequest)
+}
+
+// IsUnauthorized reports whether err is an APIError with status 401
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an APIError with status 403
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether err is an APIError with status 404
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an APIError with status 409
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+// IsPreconditionFailed reports whether err is an APIError with status 412,
+// Grafana uses it for dashboard version mismatches
+func IsPreconditionFailed(err error) bool {
+	return hasStatusCode(err, http.StatusPreconditionFailed)
+}
@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"errors"
+	"testing"
+)
+
+const (
+	dashboardNotFoundJSON = `{"message":"Dashboard not found"}`
+	versionMismatchJSON   = `{"message":"The dashboard has been changed by someone else","status":"version-mismatch"}`
+)
+
+func TestAPIErrorNotFound(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 404, dashboardNotFoundJSON)
+	defer server.Close()
+
+	_, err := client.GetDashboard("nErXDvCkzz")
+	if err == nil {
+		t.Fatal("expected an error for a 404 response")
+	}
+	rec.expect("GET", "/api/dashboards/uid/nErXDvCkzz")
+	if !IsNotFound(err) {
+		t.Errorf("expected IsNotFound to be true for %v", err)
+	}
+	if IsConflict(err) || IsUnauthorized(err) {
+		t.Errorf("unexpected error classification for %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != 404 || apiErr.Message != "Dashboard not found" {
+		t.Errorf("unexpected status or message: %d %q", apiErr.StatusCode, apiErr.Message)
+	}
+	if apiErr.Method != "GET" || apiErr.Path != "/api/dashboards/uid/nErXDvCkzz" {
+		t.Errorf("unexpected request in error: %s %s", apiErr.Method, apiErr.Path)
+	}
+	if string(apiErr.Body) != dashboardNotFoundJSON {
+		t.Errorf("expected the response body to be kept, got %s", apiErr.Body)
+	}
+}
+
+func TestAPIErrorPreconditionFailed(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 412, versionMismatchJSON)
+	defer server.Close()
+
+	_, err := client.SaveDashboard(map[string]interface{}{"title": "foo"}, false)
+	if !IsPreconditionFailed(err) {
+		t.Errorf("expected IsPreconditionFailed to be true for %v", err)
+	}
+	rec.expect("POST", "/api/dashboards/db")
+}
+
+func TestAPIErrorWithoutJSONBody(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 401, "Unauthorized")
+	defer server.Close()
+
+	err := client.DeleteOrg(1)
+	if !IsUnauthorized(err) {
+		t.Errorf("expected IsUnauthorized to be true for %v", err)
+	}
+	rec.expect("DELETE", "/api/orgs/1")
+	expected := "DELETE /api/orgs/1: status: 401, message: Unauthorized"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestIsNotFoundWithOtherErrors(t *testing.T) {
+	if IsNotFound(nil) || IsNotFound(errors.New("404 Not Found")) {
+		t.Error("IsNotFound should only match an *APIError")
+	}
+}
@@ -2,11 +2,9 @@ package gapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/url"
 )
 
 type Folder struct {
@@ -16,188 +14,123 @@ type Folder struct {
 }
 
 func (c *Client) Folders() ([]Folder, error) {
-	folders := make([]Folder, 0)
+	return c.FoldersContext(context.Background())
+}
 
-	req, err := c.newRequest("GET", "/api/folders/", nil, nil)
-	if err != nil {
-		return folders, err
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return folders, err
-	}
-	if resp.StatusCode != 200 {
-		return folders, errors.New(resp.Status)
-	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return folders, err
-	}
-	err = json.Unmarshal(data, &folders)
+// FoldersContext is like Folders but takes a context for cancellation and deadlines.
+func (c *Client) FoldersContext(ctx context.Context) ([]Folder, error) {
+	folders := make([]Folder, 0)
+	err := c.request(ctx, "GET", "/api/folders/", nil, nil, &folders)
 	return folders, err
 }
 
 func (c *Client) Folder(id int64) (*Folder, error) {
+	return c.FolderContext(context.Background(), id)
+}
+
+// FolderContext is like Folder but takes a context for cancellation and deadlines.
+func (c *Client) FolderContext(ctx context.Context, id int64) (*Folder, error) {
 	folder := &Folder{}
-	req, err := c.newRequest("GET", fmt.Sprintf("/api/folders/id/%d", id), nil, nil)
-	if err != nil {
-		return folder, err
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return folder, err
-	}
-	if resp.StatusCode != 200 {
-		return folder, errors.New(resp.Status)
-	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return folder, err
-	}
-	err = json.Unmarshal(data, &folder)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/folders/id/%d", id), nil, nil, folder)
 	return folder, err
 }
 
 // SearchFolder search a folder in Grafana
 func (c *Client) SearchFolder(query string) ([]Folder, error) {
-	folders := make([]Folder, 0)
-	path := "/api/search"
-
-	params := url.Values{}
-	params.Add("type", "dash-folder")
-	params.Add("query", query)
+	return c.SearchFolderContext(context.Background(), query)
+}
 
-	req, err := c.newRequest("GET", path, params, nil)
-	if err != nil {
-		return folders, err
-	}
-	resp, err := c.Do(req)
+// SearchFolderContext is like SearchFolder but takes a context for cancellation and deadlines.
+func (c *Client) SearchFolderContext(ctx context.Context, query string) ([]Folder, error) {
+	folders := make([]Folder, 0)
+	hits, err := c.SearchContext(ctx, SearchQuery{
+		Query: query,
+		Type:  SearchTypeFolder,
+	})
 	if err != nil {
 		return folders, err
 	}
-	if resp.StatusCode != 200 {
-		return folders, errors.New(resp.Status)
+	for _, hit := range hits {
+		folders = append(folders, Folder{
+			Id:    hit.ID,
+			Uid:   hit.UID,
+			Title: hit.Title,
+		})
 	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return folders, err
-	}
-	err = json.Unmarshal(data, &folders)
 	return folders, err
 }
 
 func (c *Client) FolderByUID(uid string) (*Folder, error) {
+	return c.FolderByUIDContext(context.Background(), uid)
+}
+
+// FolderByUIDContext is like FolderByUID but takes a context for cancellation and deadlines.
+func (c *Client) FolderByUIDContext(ctx context.Context, uid string) (*Folder, error) {
 	folder := &Folder{}
-	req, err := c.newRequest("GET", fmt.Sprintf("/api/folders/%s", uid), nil, nil)
-	if err != nil {
-		return folder, err
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return folder, err
-	}
-	if resp.StatusCode != 200 {
-		return folder, errors.New(resp.Status)
-	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return folder, err
-	}
-	err = json.Unmarshal(data, &folder)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/folders/%s", uid), nil, nil, folder)
 	return folder, err
 }
 
 // NewFolderWithUID allows to create a new folder by specifying a custom
 // UID. It is duplicated in order to maintain compatibility with existent tools
 func (c *Client) NewFolderWithUID(title, uid string) (Folder, error) {
+	return c.NewFolderWithUIDContext(context.Background(), title, uid)
+}
+
+// NewFolderWithUIDContext is like NewFolderWithUID but takes a context for cancellation and deadlines.
+func (c *Client) NewFolderWithUIDContext(ctx context.Context, title, uid string) (Folder, error) {
 	folder := Folder{}
 	dataMap := map[string]string{
 		"title": title,
 		"uid":   uid,
 	}
 	data, err := json.Marshal(dataMap)
-	req, err := c.newRequest("POST", "/api/folders", nil, bytes.NewBuffer(data))
-	if err != nil {
-		return folder, err
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return folder, err
-	}
-	if resp.StatusCode != 200 {
-		data, _ = ioutil.ReadAll(resp.Body)
-		return folder, fmt.Errorf("status: %s body: %s", resp.Status, data)
-	}
-	data, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return folder, err
-	}
-	err = json.Unmarshal(data, &folder)
 	if err != nil {
 		return folder, err
 	}
+	err = c.request(ctx, "POST", "/api/folders", nil, bytes.NewBuffer(data), &folder)
 	return folder, err
 }
 
 func (c *Client) NewFolder(title string) (Folder, error) {
+	return c.NewFolderContext(context.Background(), title)
+}
+
+// NewFolderContext is like NewFolder but takes a context for cancellation and deadlines.
+func (c *Client) NewFolderContext(ctx context.Context, title string) (Folder, error) {
 	folder := Folder{}
 	dataMap := map[string]string{
 		"title": title,
 	}
 	data, err := json.Marshal(dataMap)
-	req, err := c.newRequest("POST", "/api/folders", nil, bytes.NewBuffer(data))
-	if err != nil {
-		return folder, err
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return folder, err
-	}
-	if resp.StatusCode != 200 {
-		data, _ = ioutil.ReadAll(resp.Body)
-		return folder, fmt.Errorf("status: %s body: %s", resp.Status, data)
-	}
-	data, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return folder, err
-	}
-	err = json.Unmarshal(data, &folder)
 	if err != nil {
 		return folder, err
 	}
+	err = c.request(ctx, "POST", "/api/folders", nil, bytes.NewBuffer(data), &folder)
 	return folder, err
 }
 
 func (c *Client) UpdateFolder(id string, name string) error {
+	return c.UpdateFolderContext(context.Background(), id, name)
+}
+
+// UpdateFolderContext is like UpdateFolder but takes a context for cancellation and deadlines.
+func (c *Client) UpdateFolderContext(ctx context.Context, id string, name string) error {
 	dataMap := map[string]string{
 		"name": name,
 	}
 	data, err := json.Marshal(dataMap)
-	req, err := c.newRequest("PUT", fmt.Sprintf("/api/folders/%s", id), nil, bytes.NewBuffer(data))
-	if err != nil {
-		return err
-	}
-	resp, err := c.Do(req)
 	if err != nil {
 		return err
 	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
-	}
-	return err
+	return c.request(ctx, "PUT", fmt.Sprintf("/api/folders/%s", id), nil, bytes.NewBuffer(data), nil)
 }
 
 func (c *Client) DeleteFolder(id string) error {
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/api/folders/%s", id), nil, nil)
-	if err != nil {
-		return err
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
-	}
-	return err
+	return c.DeleteFolderContext(context.Background(), id)
+}
+
+// DeleteFolderContext is like DeleteFolder but takes a context for cancellation and deadlines.
+func (c *Client) DeleteFolderContext(ctx context.Context, id string) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/folders/%s", id), nil, nil, nil)
 }
@@ -0,0 +1,185 @@
+package gapi
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// LimiterStats reports how requests were throttled by the client side limits
+type LimiterStats struct {
+	// Requests is the number of requests that passed the limiter
+	Requests int64
+	// Throttled is the number of requests that had to wait
+	Throttled int64
+	// RateLimitWait is the total time spent waiting for the rate limit
+	RateLimitWait time.Duration
+	// ConcurrencyWait is the total time spent waiting for a free in-flight slot
+	ConcurrencyWait time.Duration
+	// InFlight is the number of requests currently in flight
+	InFlight int
+}
+
+// limiter applies the RateLimit and MaxInFlight settings of a ClientConfig,
+// it is shared by all copies of a Client
+type limiter struct {
+	bucket *tokenBucket
+	slots  chan struct{}
+
+	mu    sync.Mutex
+	stats LimiterStats
+}
+
+func newLimiter(config ClientConfig) *limiter {
+	if config.RateLimit <= 0 && config.MaxInFlight <= 0 {
+		return nil
+	}
+	l := &limiter{}
+	if config.RateLimit > 0 {
+		l.bucket = newTokenBucket(config.RateLimit, config.RateBurst)
+	}
+	if config.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, config.MaxInFlight)
+	}
+	return l
+}
+
+// wait blocks until the request may be sent, the returned func has to be
+// called once the request is done
+func (l *limiter) wait(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	var rateWait, slotWait time.Duration
+	if l.bucket != nil {
+		start := time.Now()
+		if err := l.bucket.wait(ctx); err != nil {
+			return nil, err
+		}
+		rateWait = time.Since(start)
+	}
+	if l.slots != nil {
+		start := time.Now()
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			// the request is not sent, so it must not use up the rate limit
+			if l.bucket != nil {
+				l.bucket.cancel()
+			}
+			return nil, ctx.Err()
+		}
+		slotWait = time.Since(start)
+	}
+
+	l.mu.Lock()
+	l.stats.Requests++
+	l.stats.InFlight++
+	if rateWait > 0 || slotWait > 0 {
+		l.stats.Throttled++
+	}
+	l.stats.RateLimitWait += rateWait
+	l.stats.ConcurrencyWait += slotWait
+	l.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			l.stats.InFlight--
+			l.mu.Unlock()
+			if l.slots != nil {
+				<-l.slots
+			}
+		})
+	}, nil
+}
+
+func (l *limiter) snapshot() LimiterStats {
+	if l == nil {
+		return LimiterStats{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// LimiterStats returns the statistics of the rate and concurrency limits
+// configured for the client
+func (c *Client) LimiterStats() LimiterStats {
+	return c.limiter.snapshot()
+}
+
+// tokenBucket allows rate requests per second with bursts of up to burst requests
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long to wait until it is available
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	wait := b.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// releaseBody frees the limiter slot of a request once its body is closed
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
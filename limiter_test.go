@@ -0,0 +1,144 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMaxInFlight(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprint(w, createdDataSourceJSON)
+	}))
+	defer server.Close()
+
+	client, err := NewWithConfig("my-key", server.URL, ClientConfig{MaxInFlight: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := client.NewDataSource(&DataSource{Name: fmt.Sprintf("ds-%d", i)}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+	stats := client.LimiterStats()
+	if stats.Requests != 10 || stats.InFlight != 0 {
+		t.Errorf("unexpected limiter stats: %+v", stats)
+	}
+	if stats.Throttled == 0 || stats.ConcurrencyWait <= 0 {
+		t.Errorf("expected requests to wait for a free slot: %+v", stats)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, getFoldersJSON)
+	}))
+	defer server.Close()
+
+	client, err := NewWithConfig("my-key", server.URL, ClientConfig{RateLimit: 20, RateBurst: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := client.Folders(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected 5 requests at 20/s to take at least 200ms, took %s", elapsed)
+	}
+	if stats := client.LimiterStats(); stats.Requests != 5 || stats.RateLimitWait <= 0 {
+		t.Errorf("unexpected limiter stats: %+v", stats)
+	}
+}
+
+func TestRateLimitRespectsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, getFoldersJSON)
+	}))
+	defer server.Close()
+
+	client, err := NewWithConfig("my-key", server.URL, ClientConfig{RateLimit: 0.1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Folders(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.FoldersContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestLimiterReturnsTokenOnCancel(t *testing.T) {
+	l := newLimiter(ClientConfig{RateLimit: 0.001, RateBurst: 2, MaxInFlight: 1})
+
+	release, err := l.wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded while waiting for a slot, got %v", err)
+	}
+	release()
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	release, err = l.wait(ctx)
+	if err != nil {
+		t.Fatalf("expected the token of the cancelled request to be returned, got %v", err)
+	}
+	release()
+	if stats := l.snapshot(); stats.Requests != 2 || stats.RateLimitWait > 100*time.Millisecond {
+		t.Errorf("unexpected limiter stats: %+v", stats)
+	}
+}
+
+func TestLimiterStatsWithoutLimits(t *testing.T) {
+	client, err := New("my-key", "http://my-grafana.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats := client.LimiterStats(); stats != (LimiterStats{}) {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+}
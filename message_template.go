@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// MessageTemplate is a named notification template, Template holds the
+// Go template definitions used by contact points
+type MessageTemplate struct {
+	Name       string `json:"name"`
+	Template   string `json:"template"`
+	Provenance string `json:"provenance,omitempty"`
+}
+
+// MessageTemplates lists all notification templates
+func (c *Client) MessageTemplates() ([]MessageTemplate, error) {
+	return c.MessageTemplatesContext(context.Background())
+}
+
+// MessageTemplatesContext is like MessageTemplates but takes a context for cancellation and deadlines.
+func (c *Client) MessageTemplatesContext(ctx context.Context) ([]MessageTemplate, error) {
+	templates := make([]MessageTemplate, 0)
+	err := c.request(ctx, "GET", "/api/v1/provisioning/templates", nil, nil, &templates)
+	return templates, err
+}
+
+// MessageTemplate returns the notification template with the given name
+func (c *Client) MessageTemplate(name string) (*MessageTemplate, error) {
+	return c.MessageTemplateContext(context.Background(), name)
+}
+
+// MessageTemplateContext is like MessageTemplate but takes a context for cancellation and deadlines.
+func (c *Client) MessageTemplateContext(ctx context.Context, name string) (*MessageTemplate, error) {
+	result := &MessageTemplate{}
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/v1/provisioning/templates/%s", name), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// SetMessageTemplate creates or replaces the notification template with the given name
+func (c *Client) SetMessageTemplate(name, template string) error {
+	return c.SetMessageTemplateContext(context.Background(), name, template)
+}
+
+// SetMessageTemplateContext is like SetMessageTemplate but takes a context for cancellation and deadlines.
+func (c *Client) SetMessageTemplateContext(ctx context.Context, name, template string) error {
+	data, err := json.Marshal(map[string]string{
+		"template": template,
+	})
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PUT", fmt.Sprintf("/api/v1/provisioning/templates/%s", name), nil, bytes.NewBuffer(data), nil)
+}
+
+// DeleteMessageTemplate deletes the notification template with the given name
+func (c *Client) DeleteMessageTemplate(name string) error {
+	return c.DeleteMessageTemplateContext(context.Background(), name)
+}
+
+// DeleteMessageTemplateContext is like DeleteMessageTemplate but takes a context for cancellation and deadlines.
+func (c *Client) DeleteMessageTemplateContext(ctx context.Context, name string) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/v1/provisioning/templates/%s", name), nil, nil, nil)
+}
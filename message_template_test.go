@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getMessageTemplatesJSON = `
+[
+  {
+    "name": "slack.title",
+    "template": "{{ define \"slack.title\" }}[{{ .Status }}] {{ .CommonLabels.alertname }}{{ end }}",
+    "provenance": "api"
+  }
+]
+`
+)
+
+func TestMessageTemplates(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getMessageTemplatesJSON)
+	defer server.Close()
+
+	templates, err := client.MessageTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/v1/provisioning/templates")
+
+	t.Log(pretty.PrettyFormat(templates))
+
+	if len(templates) != 1 || templates[0].Name != "slack.title" || templates[0].Template == "" {
+		t.Error("Not correctly parsing returned message templates.")
+	}
+}
+
+func TestSetMessageTemplate(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 202, "")
+	defer server.Close()
+
+	err := client.SetMessageTemplate("slack.title", `{{ define "slack.title" }}{{ .Status }}{{ end }}`)
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/v1/provisioning/templates/slack.title")
+}
+
+func TestDeleteMessageTemplate(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 204, "")
+	defer server.Close()
+
+	err := client.DeleteMessageTemplate("slack.title")
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/v1/provisioning/templates/slack.title")
+}
@@ -0,0 +1,103 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// MuteTiming is a named set of time intervals in which notifications of
+// the routes referencing it are muted
+type MuteTiming struct {
+	Name          string         `json:"name"`
+	TimeIntervals []TimeInterval `json:"time_intervals"`
+	Provenance    string         `json:"provenance,omitempty"`
+}
+
+// TimeInterval matches the times which are in all of its set fields,
+// e.g. Weekdays "monday:friday" and Times 09:00 to 17:00
+type TimeInterval struct {
+	Times       []TimeOfDayRange `json:"times,omitempty"`
+	Weekdays    []string         `json:"weekdays,omitempty"`
+	DaysOfMonth []string         `json:"days_of_month,omitempty"`
+	Months      []string         `json:"months,omitempty"`
+	Years       []string         `json:"years,omitempty"`
+	Location    string           `json:"location,omitempty"`
+}
+
+// TimeOfDayRange is a range of the day in the HH:MM format
+type TimeOfDayRange struct {
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// MuteTimings lists all mute timings
+func (c *Client) MuteTimings() ([]MuteTiming, error) {
+	return c.MuteTimingsContext(context.Background())
+}
+
+// MuteTimingsContext is like MuteTimings but takes a context for cancellation and deadlines.
+func (c *Client) MuteTimingsContext(ctx context.Context) ([]MuteTiming, error) {
+	timings := make([]MuteTiming, 0)
+	err := c.request(ctx, "GET", "/api/v1/provisioning/mute-timings", nil, nil, &timings)
+	return timings, err
+}
+
+// MuteTiming returns the mute timing with the given name
+func (c *Client) MuteTiming(name string) (*MuteTiming, error) {
+	return c.MuteTimingContext(context.Background(), name)
+}
+
+// MuteTimingContext is like MuteTiming but takes a context for cancellation and deadlines.
+func (c *Client) MuteTimingContext(ctx context.Context, name string) (*MuteTiming, error) {
+	result := &MuteTiming{}
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/v1/provisioning/mute-timings/%s", name), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// NewMuteTiming creates a mute timing
+func (c *Client) NewMuteTiming(m *MuteTiming) (*MuteTiming, error) {
+	return c.NewMuteTimingContext(context.Background(), m)
+}
+
+// NewMuteTimingContext is like NewMuteTiming but takes a context for cancellation and deadlines.
+func (c *Client) NewMuteTimingContext(ctx context.Context, m *MuteTiming) (*MuteTiming, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	result := &MuteTiming{}
+	err = c.request(ctx, "POST", "/api/v1/provisioning/mute-timings", nil, bytes.NewBuffer(data), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// UpdateMuteTiming replaces the time intervals of the mute timing with the name of m
+func (c *Client) UpdateMuteTiming(m *MuteTiming) error {
+	return c.UpdateMuteTimingContext(context.Background(), m)
+}
+
+// UpdateMuteTimingContext is like UpdateMuteTiming but takes a context for cancellation and deadlines.
+func (c *Client) UpdateMuteTimingContext(ctx context.Context, m *MuteTiming) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PUT", fmt.Sprintf("/api/v1/provisioning/mute-timings/%s", m.Name), nil, bytes.NewBuffer(data), nil)
+}
+
+// DeleteMuteTiming deletes the mute timing with the given name
+func (c *Client) DeleteMuteTiming(name string) error {
+	return c.DeleteMuteTimingContext(context.Background(), name)
+}
+
+// DeleteMuteTimingContext is like DeleteMuteTiming but takes a context for cancellation and deadlines.
+func (c *Client) DeleteMuteTimingContext(ctx context.Context, name string) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/v1/provisioning/mute-timings/%s", name), nil, nil, nil)
+}
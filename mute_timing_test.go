@@ -0,0 +1,97 @@
+package gapi
+
+import (
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getMuteTimingJSON = `
+{
+  "name": "weekends",
+  "time_intervals": [
+    {
+      "times": [{"start_time": "00:00", "end_time": "23:59"}],
+      "weekdays": ["saturday", "sunday"],
+      "location": "Europe/Berlin"
+    }
+  ],
+  "provenance": "api"
+}
+`
+	getMuteTimingsJSON = `[` + getMuteTimingJSON + `]`
+)
+
+func TestMuteTimings(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getMuteTimingsJSON)
+	defer server.Close()
+
+	timings, err := client.MuteTimings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/v1/provisioning/mute-timings")
+
+	t.Log(pretty.PrettyFormat(timings))
+
+	if len(timings) != 1 || timings[0].Name != "weekends" {
+		t.Error("Not correctly parsing returned mute timings.")
+	}
+}
+
+func TestMuteTiming(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getMuteTimingJSON)
+	defer server.Close()
+
+	timing, err := client.MuteTiming("weekends")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/v1/provisioning/mute-timings/weekends")
+
+	interval := timing.TimeIntervals[0]
+	if len(interval.Weekdays) != 2 || interval.Times[0].EndTime != "23:59" || interval.Location != "Europe/Berlin" {
+		t.Error("Not correctly parsing returned mute timing.")
+	}
+}
+
+func TestNewMuteTiming(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 201, getMuteTimingJSON)
+	defer server.Close()
+
+	timing, err := client.NewMuteTiming(&MuteTiming{
+		Name:          "weekends",
+		TimeIntervals: []TimeInterval{{Weekdays: []string{"saturday", "sunday"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("POST", "/api/v1/provisioning/mute-timings")
+
+	if timing.Name != "weekends" || timing.Provenance != "api" {
+		t.Error("mute timing creation response should return the created mute timing")
+	}
+}
+
+func TestUpdateMuteTiming(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getMuteTimingJSON)
+	defer server.Close()
+
+	err := client.UpdateMuteTiming(&MuteTiming{Name: "weekends"})
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/v1/provisioning/mute-timings/weekends")
+}
+
+func TestDeleteMuteTiming(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 204, "")
+	defer server.Close()
+
+	err := client.DeleteMuteTiming("weekends")
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/v1/provisioning/mute-timings/weekends")
+}
@@ -0,0 +1,227 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MatchType is the operator of a Matcher
+type MatchType string
+
+// Operators of label matchers
+const (
+	MatchEqual     MatchType = "="
+	MatchNotEqual  MatchType = "!="
+	MatchRegexp    MatchType = "=~"
+	MatchNotRegexp MatchType = "!~"
+)
+
+// Matcher matches the alert label Name against Value,
+// it is encoded as ["name", "=", "value"] tuple
+type Matcher struct {
+	Name  string
+	Type  MatchType
+	Value string
+}
+
+func (m Matcher) String() string {
+	return fmt.Sprintf("%s%s%q", m.Name, m.Type, m.Value)
+}
+
+// MarshalJSON encodes the matcher as tuple
+func (m Matcher) MarshalJSON() ([]byte, error) {
+	return json.Marshal([3]string{m.Name, string(m.Type), m.Value})
+}
+
+// UnmarshalJSON decodes the matcher from a tuple
+func (m *Matcher) UnmarshalJSON(data []byte) error {
+	var tuple []string
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+	if len(tuple) != 3 {
+		return fmt.Errorf("invalid matcher %s, expected [name, type, value]", data)
+	}
+	m.Name, m.Type, m.Value = tuple[0], MatchType(tuple[1]), tuple[2]
+	return nil
+}
+
+// Matchers is the set of matchers of a route, an alert has to match all of them
+type Matchers []Matcher
+
+func (ms Matchers) String() string {
+	s := make([]string, len(ms))
+	for i, m := range ms {
+		s[i] = m.String()
+	}
+	return "{" + strings.Join(s, ", ") + "}"
+}
+
+// Equal reports whether both sets contain the same matchers regardless of their order
+func (ms Matchers) Equal(other Matchers) bool {
+	if len(ms) != len(other) {
+		return false
+	}
+	for _, m := range ms {
+		found := false
+		for _, o := range other {
+			if m == o {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Route is a node of the notification policy tree, the root route has no
+// matchers and defines the default receiver. Keys without a field, like the
+// legacy matchers or active_time_intervals, are kept in Extra so that a
+// tree read from Grafana can be written back unchanged.
+type Route struct {
+	Receiver          string   `json:"receiver,omitempty"`
+	GroupBy           []string `json:"group_by,omitempty"`
+	ObjectMatchers    Matchers `json:"object_matchers,omitempty"`
+	MuteTimeIntervals []string `json:"mute_time_intervals,omitempty"`
+	Continue          bool     `json:"continue,omitempty"`
+	GroupWait         string   `json:"group_wait,omitempty"`
+	GroupInterval     string   `json:"group_interval,omitempty"`
+	RepeatInterval    string   `json:"repeat_interval,omitempty"`
+	Routes            []*Route `json:"routes,omitempty"`
+	Provenance        string   `json:"provenance,omitempty"`
+
+	Extra map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON encodes the fields together with the Extra keys
+func (r Route) MarshalJSON() ([]byte, error) {
+	type route Route
+	return marshalWithExtra(route(r), r.Extra)
+}
+
+// UnmarshalJSON decodes the known keys into the fields and all others into Extra
+func (r *Route) UnmarshalJSON(data []byte) error {
+	type route Route
+	known := route{}
+	extra, err := unmarshalWithExtra(data, &known)
+	if err != nil {
+		return err
+	}
+	*r = Route(known)
+	r.Extra = extra
+	return nil
+}
+
+// FindRoute returns the first route of the tree, including r itself,
+// whose matchers equal the given ones or nil if there is none
+func (r *Route) FindRoute(matchers Matchers) *Route {
+	if r.ObjectMatchers.Equal(matchers) {
+		return r
+	}
+	for _, child := range r.Routes {
+		if found := child.FindRoute(matchers); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// RemoveRoute removes the first route below r whose matchers equal the
+// given ones, including its children, and reports whether one was found
+func (r *Route) RemoveRoute(matchers Matchers) bool {
+	for i, child := range r.Routes {
+		if child.ObjectMatchers.Equal(matchers) {
+			r.Routes = append(r.Routes[:i:i], r.Routes[i+1:]...)
+			return true
+		}
+		if child.RemoveRoute(matchers) {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationPolicyTree returns the root route of the notification policy tree
+func (c *Client) NotificationPolicyTree() (*Route, error) {
+	return c.NotificationPolicyTreeContext(context.Background())
+}
+
+// NotificationPolicyTreeContext is like NotificationPolicyTree but takes a context for cancellation and deadlines.
+func (c *Client) NotificationPolicyTreeContext(ctx context.Context) (*Route, error) {
+	result := &Route{}
+	err := c.request(ctx, "GET", "/api/v1/provisioning/policies", nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// SetNotificationPolicyTree replaces the whole notification policy tree
+func (c *Client) SetNotificationPolicyTree(root *Route) error {
+	return c.SetNotificationPolicyTreeContext(context.Background(), root)
+}
+
+// SetNotificationPolicyTreeContext is like SetNotificationPolicyTree but takes a context for cancellation and deadlines.
+func (c *Client) SetNotificationPolicyTreeContext(ctx context.Context, root *Route) error {
+	data, err := json.Marshal(root)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PUT", "/api/v1/provisioning/policies", nil, bytes.NewBuffer(data), nil)
+}
+
+// ResetNotificationPolicyTree resets the notification policy tree to the default
+func (c *Client) ResetNotificationPolicyTree() error {
+	return c.ResetNotificationPolicyTreeContext(context.Background())
+}
+
+// ResetNotificationPolicyTreeContext is like ResetNotificationPolicyTree but takes a context for cancellation and deadlines.
+func (c *Client) ResetNotificationPolicyTreeContext(ctx context.Context) error {
+	return c.request(ctx, "DELETE", "/api/v1/provisioning/policies", nil, nil, nil)
+}
+
+// AddNotificationPolicy appends route to the children of the route matching
+// parent, an empty parent adds it below the root. The tree is read and
+// written back, concurrent changes in between are lost.
+func (c *Client) AddNotificationPolicy(parent Matchers, route *Route) error {
+	return c.AddNotificationPolicyContext(context.Background(), parent, route)
+}
+
+// AddNotificationPolicyContext is like AddNotificationPolicy but takes a context for cancellation and deadlines.
+func (c *Client) AddNotificationPolicyContext(ctx context.Context, parent Matchers, route *Route) error {
+	root, err := c.NotificationPolicyTreeContext(ctx)
+	if err != nil {
+		return err
+	}
+	node := root.FindRoute(parent)
+	if node == nil {
+		return fmt.Errorf("no notification policy matching %s", parent)
+	}
+	node.Routes = append(node.Routes, route)
+	return c.SetNotificationPolicyTreeContext(ctx, root)
+}
+
+// RemoveNotificationPolicy removes the first route matching matchers from the
+// notification policy tree and reports whether one was found. The tree is
+// only written back if it changed.
+func (c *Client) RemoveNotificationPolicy(matchers Matchers) (bool, error) {
+	return c.RemoveNotificationPolicyContext(context.Background(), matchers)
+}
+
+// RemoveNotificationPolicyContext is like RemoveNotificationPolicy but takes a context for cancellation and deadlines.
+func (c *Client) RemoveNotificationPolicyContext(ctx context.Context, matchers Matchers) (bool, error) {
+	root, err := c.NotificationPolicyTreeContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !root.RemoveRoute(matchers) {
+		return false, nil
+	}
+	return true, c.SetNotificationPolicyTreeContext(ctx, root)
+}
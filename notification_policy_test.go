@@ -0,0 +1,189 @@
+package gapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getNotificationPolicyTreeJSON = `
+{
+  "receiver": "grafana-default-email",
+  "group_by": ["grafana_folder", "alertname"],
+  "routes": [
+    {
+      "receiver": "infra-slack",
+      "object_matchers": [["team", "=", "infra"]],
+      "active_time_intervals": ["business-hours"],
+      "routes": [
+        {
+          "receiver": "infra-pager",
+          "object_matchers": [["severity", "=~", "critical|page"], ["team", "=", "infra"]],
+          "mute_time_intervals": ["weekends"]
+        }
+      ]
+    },
+    {
+      "receiver": "web-slack",
+      "object_matchers": [["team", "=", "web"]],
+      "continue": true
+    },
+    {
+      "receiver": "legacy-email",
+      "matchers": ["env=\"prod\""],
+      "match": {"service": "api"},
+      "match_re": {"region": "eu-.*"}
+    }
+  ],
+  "group_wait": "30s",
+  "provenance": "api"
+}
+`
+)
+
+func TestNotificationPolicyTree(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getNotificationPolicyTreeJSON)
+	defer server.Close()
+
+	root, err := client.NotificationPolicyTree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/v1/provisioning/policies")
+
+	t.Log(pretty.PrettyFormat(root))
+
+	if root.Receiver != "grafana-default-email" || len(root.Routes) != 3 || len(root.Routes[0].Routes) != 1 {
+		t.Fatal("Not correctly parsing returned notification policy tree.")
+	}
+	critical := root.Routes[0].Routes[0].ObjectMatchers
+	if len(critical) != 2 || critical[0] != (Matcher{Name: "severity", Type: MatchRegexp, Value: "critical|page"}) {
+		t.Error("Not correctly parsing the matchers of a route.")
+	}
+}
+
+func TestRouteRoundTrip(t *testing.T) {
+	root := &Route{}
+	if err := json.Unmarshal([]byte(getNotificationPolicyTreeJSON), root); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected, actual interface{}
+	if err := json.Unmarshal([]byte(getNotificationPolicyTreeJSON), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected the tree to be written back unchanged, got %s", data)
+	}
+}
+
+func TestMatcherJSON(t *testing.T) {
+	data, err := json.Marshal(Matchers{{Name: "team", Type: MatchNotEqual, Value: "web"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `[["team","!=","web"]]` {
+		t.Errorf("expected matchers to be encoded as tuples, got %s", data)
+	}
+
+	var m Matcher
+	if err := json.Unmarshal([]byte(`["team", "="]`), &m); err == nil {
+		t.Error("expected an error for a matcher without value")
+	}
+}
+
+func TestFindRoute(t *testing.T) {
+	root := &Route{}
+	if err := json.Unmarshal([]byte(getNotificationPolicyTreeJSON), root); err != nil {
+		t.Fatal(err)
+	}
+
+	if root.FindRoute(nil) != root {
+		t.Error("expected empty matchers to find the root route")
+	}
+	found := root.FindRoute(Matchers{
+		{Name: "team", Type: MatchEqual, Value: "infra"},
+		{Name: "severity", Type: MatchRegexp, Value: "critical|page"},
+	})
+	if found == nil || found.Receiver != "infra-pager" {
+		t.Error("expected to find the nested route regardless of the matcher order")
+	}
+	if root.FindRoute(Matchers{{Name: "team", Type: MatchEqual, Value: "db"}}) != nil {
+		t.Error("expected no route for unknown matchers")
+	}
+}
+
+func TestAddNotificationPolicy(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getNotificationPolicyTreeJSON)
+	defer server.Close()
+	rec.respond("PUT", "", 200, `{"message":"policies updated"}`)
+
+	parent := Matchers{{Name: "team", Type: MatchEqual, Value: "infra"}}
+	route := &Route{
+		Receiver:       "infra-email",
+		ObjectMatchers: Matchers{{Name: "team", Type: MatchEqual, Value: "infra"}, {Name: "severity", Type: MatchEqual, Value: "info"}},
+	}
+	if err := client.AddNotificationPolicy(parent, route); err != nil {
+		t.Fatal(err)
+	}
+
+	root := &Route{}
+	if err := json.Unmarshal([]byte(rec.expect("PUT", "/api/v1/provisioning/policies").Body), root); err != nil {
+		t.Fatal(err)
+	}
+	if len(root.Routes) != 3 || len(root.Routes[0].Routes) != 2 || root.Routes[0].Routes[1].Receiver != "infra-email" {
+		t.Errorf("expected the route to be appended below its parent, got %s", pretty.PrettyFormat(root))
+	}
+	if root.Receiver != "grafana-default-email" || !root.Routes[1].Continue {
+		t.Error("expected the rest of the tree to be kept")
+	}
+	if !reflect.DeepEqual(root.Routes[0].Extra["active_time_intervals"], []interface{}{"business-hours"}) || root.Routes[2].Extra["match_re"] == nil {
+		t.Errorf("expected keys without a field to be kept, got %s", pretty.PrettyFormat(root))
+	}
+
+	if err := client.AddNotificationPolicy(Matchers{{Name: "team", Type: MatchEqual, Value: "db"}}, route); err == nil {
+		t.Error("expected an error for an unknown parent")
+	}
+}
+
+func TestRemoveNotificationPolicy(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getNotificationPolicyTreeJSON)
+	defer server.Close()
+	rec.respond("PUT", "", 200, `{"message":"policies updated"}`)
+
+	removed, err := client.RemoveNotificationPolicy(Matchers{{Name: "team", Type: MatchEqual, Value: "web"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := &Route{}
+	if err := json.Unmarshal([]byte(rec.expect("PUT", "/api/v1/provisioning/policies").Body), root); err != nil {
+		t.Fatal(err)
+	}
+	if !removed || len(root.Routes) != 2 || root.Routes[0].Receiver != "infra-slack" || root.Routes[1].Receiver != "legacy-email" {
+		t.Errorf("expected the web route to be removed, got %s", pretty.PrettyFormat(root))
+	}
+}
+
+func TestRemoveNotificationPolicyNotFound(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getNotificationPolicyTreeJSON)
+	defer server.Close()
+	rec.respond("PUT", "", 200, `{"message":"policies updated"}`)
+
+	removed, err := client.RemoveNotificationPolicy(Matchers{{Name: "team", Type: MatchEqual, Value: "db"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed || len(rec.all()) != 1 || rec.last().Method != "GET" {
+		t.Error("expected the tree not to be written back if no route was removed")
+	}
+}
@@ -0,0 +1,113 @@
+package gapi
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+)
+
+// Option configures a Client created by NewClient
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	auth       AuthProvider
+	orgID      int64
+	headers    http.Header
+	userAgent  string
+	httpClient *http.Client
+	tlsConfig  *tls.Config
+	config     ClientConfig
+}
+
+// WithAuth authenticates all requests with the given provider
+func WithAuth(provider AuthProvider) Option {
+	return func(o *clientOptions) {
+		o.auth = provider
+	}
+}
+
+// WithBasicAuth authenticates all requests with a Grafana user and password
+func WithBasicAuth(username, password string) Option {
+	return WithAuth(BasicAuth{Username: username, Password: password})
+}
+
+// WithAPIKey authenticates all requests with a Grafana API key
+func WithAPIKey(key string) Option {
+	return WithAuth(BearerToken(key))
+}
+
+// WithServiceAccountToken authenticates all requests with a service account token
+func WithServiceAccountToken(token string) Option {
+	return WithAuth(BearerToken(token))
+}
+
+// WithOrgID sends all requests in the context of the given organization
+// using the X-Grafana-Org-Id header
+func WithOrgID(orgID int64) Option {
+	return func(o *clientOptions) {
+		o.orgID = orgID
+	}
+}
+
+// WithHTTPClient sends all requests with the given http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTLSConfig uses the given TLS config for all connections, the transport
+// of a client passed with WithHTTPClient has to be an *http.Transport
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *clientOptions) {
+		o.tlsConfig = config
+	}
+}
+
+// WithHeader adds a header to all requests
+func WithHeader(key, value string) Option {
+	return func(o *clientOptions) {
+		o.headers.Add(key, value)
+	}
+}
+
+// WithUserAgent sets the User-Agent header of all requests
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithConfig sets the retry and limit settings of the client
+func WithConfig(config ClientConfig) Option {
+	return func(o *clientOptions) {
+		o.config = config
+	}
+}
+
+// buildHTTPClient returns the http.Client for the options, a client passed
+// with WithHTTPClient is copied instead of modified to apply the TLS config
+func (o *clientOptions) buildHTTPClient() (*http.Client, error) {
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	if o.tlsConfig == nil {
+		return httpClient, nil
+	}
+
+	var transport *http.Transport
+	switch t := httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return nil, errors.New("WithTLSConfig requires the transport of the http client to be an *http.Transport")
+	}
+	transport.TLSClientConfig = o.tlsConfig
+
+	withTLS := *httpClient
+	withTLS.Transport = transport
+	return &withTLS, nil
+}
@@ -25,11 +25,12 @@ func TestOrgUsers(t *testing.T) {
 	t.Log(pretty.PrettyFormat(resp))
 
 	user := OrgUser{
-		OrgId:  1,
-		UserId: 1,
-		Email:  "admin@localhost",
-		Login:  "admin",
-		Role:   "Admin",
+		OrgId:     1,
+		UserId:    1,
+		Email:     "admin@localhost",
+		Login:     "admin",
+		AvatarUrl: "/avatar/46d229b033af06a191ff2267bca9ae56",
+		Role:      "Admin",
 	}
 
 	if resp[0] != user {
@@ -2,143 +2,124 @@ package gapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
 )
 
 type Org struct {
-	Id   int64  `json:"id"`
-	Name string `json:"name"`
+	Id      int64       `json:"id"`
+	Name    string      `json:"name"`
+	Address *OrgAddress `json:"address,omitempty"`
+}
+
+// OrgAddress is the postal address of an organization
+type OrgAddress struct {
+	Address1 string `json:"address1"`
+	Address2 string `json:"address2"`
+	City     string `json:"city"`
+	ZipCode  string `json:"zipCode"`
+	State    string `json:"state"`
+	Country  string `json:"country"`
 }
 
 func (c *Client) Orgs() ([]Org, error) {
-	orgs := make([]Org, 0)
+	return c.OrgsContext(context.Background())
+}
 
-	req, err := c.newRequest("GET", "/api/orgs/", nil, nil)
-	if err != nil {
-		return orgs, err
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return orgs, err
-	}
-	if resp.StatusCode != 200 {
-		return orgs, errors.New(resp.Status)
-	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return orgs, err
-	}
-	err = json.Unmarshal(data, &orgs)
+// OrgsContext is like Orgs but takes a context for cancellation and deadlines.
+func (c *Client) OrgsContext(ctx context.Context) ([]Org, error) {
+	orgs := make([]Org, 0)
+	err := c.request(ctx, "GET", "/api/orgs/", nil, nil, &orgs)
 	return orgs, err
 }
 
 func (c *Client) OrgByName(name string) (Org, error) {
+	return c.OrgByNameContext(context.Background(), name)
+}
+
+// OrgByNameContext is like OrgByName but takes a context for cancellation and deadlines.
+func (c *Client) OrgByNameContext(ctx context.Context, name string) (Org, error) {
 	org := Org{}
-	req, err := c.newRequest("GET", fmt.Sprintf("/api/orgs/name/%s", name), nil, nil)
-	if err != nil {
-		return org, err
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return org, err
-	}
-	if resp.StatusCode != 200 {
-		return org, errors.New(resp.Status)
-	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return org, err
-	}
-	err = json.Unmarshal(data, &org)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/orgs/name/%s", name), nil, nil, &org)
 	return org, err
 }
 
 func (c *Client) Org(id int64) (Org, error) {
+	return c.OrgContext(context.Background(), id)
+}
+
+// OrgContext is like Org but takes a context for cancellation and deadlines.
+func (c *Client) OrgContext(ctx context.Context, id int64) (Org, error) {
 	org := Org{}
-	req, err := c.newRequest("GET", fmt.Sprintf("/api/orgs/%d", id), nil, nil)
-	if err != nil {
-		return org, err
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return org, err
-	}
-	if resp.StatusCode != 200 {
-		return org, errors.New(resp.Status)
-	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return org, err
-	}
-	err = json.Unmarshal(data, &org)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/orgs/%d", id), nil, nil, &org)
 	return org, err
 }
 
 func (c *Client) NewOrg(name string) (int64, error) {
+	return c.NewOrgContext(context.Background(), name)
+}
+
+// NewOrgContext is like NewOrg but takes a context for cancellation and deadlines.
+func (c *Client) NewOrgContext(ctx context.Context, name string) (int64, error) {
 	dataMap := map[string]string{
 		"name": name,
 	}
 	data, err := json.Marshal(dataMap)
-	id := int64(0)
-	req, err := c.newRequest("POST", "/api/orgs", nil, bytes.NewBuffer(data))
-	if err != nil {
-		return id, err
-	}
-	resp, err := c.Do(req)
 	if err != nil {
-		return id, err
-	}
-	if resp.StatusCode != 200 {
-		return id, errors.New(resp.Status)
-	}
-	data, err = ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return id, err
+		return 0, err
 	}
 	tmp := struct {
 		Id int64 `json:"orgId"`
 	}{}
-	err = json.Unmarshal(data, &tmp)
-	if err != nil {
-		return id, err
-	}
-	id = tmp.Id
-	return id, err
+	err = c.request(ctx, "POST", "/api/orgs", nil, bytes.NewBuffer(data), &tmp)
+	return tmp.Id, err
 }
 
 func (c *Client) UpdateOrg(id int64, name string) error {
+	return c.UpdateOrgContext(context.Background(), id, name)
+}
+
+// UpdateOrgContext is like UpdateOrg but takes a context for cancellation and deadlines.
+func (c *Client) UpdateOrgContext(ctx context.Context, id int64, name string) error {
 	dataMap := map[string]string{
 		"name": name,
 	}
 	data, err := json.Marshal(dataMap)
-	req, err := c.newRequest("PUT", fmt.Sprintf("/api/orgs/%d", id), nil, bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
-	}
-	return err
+	return c.request(ctx, "PUT", fmt.Sprintf("/api/orgs/%d", id), nil, bytes.NewBuffer(data), nil)
 }
 
 func (c *Client) DeleteOrg(id int64) error {
-	req, err := c.newRequest("DELETE", fmt.Sprintf("/api/orgs/%d", id), nil, nil)
-	if err != nil {
-		return err
-	}
-	resp, err := c.Do(req)
+	return c.DeleteOrgContext(context.Background(), id)
+}
+
+// DeleteOrgContext is like DeleteOrg but takes a context for cancellation and deadlines.
+func (c *Client) DeleteOrgContext(ctx context.Context, id int64) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/orgs/%d", id), nil, nil, nil)
+}
+
+// ForEachOrg calls fn for every organization with a client scoped to it,
+// it stops at the first error returned by fn
+func (c *Client) ForEachOrg(fn func(org Org, client *Client) error) error {
+	return c.ForEachOrgContext(context.Background(), fn)
+}
+
+// ForEachOrgContext is like ForEachOrg but takes a context for cancellation and deadlines.
+func (c *Client) ForEachOrgContext(ctx context.Context, fn func(org Org, client *Client) error) error {
+	orgs, err := c.OrgsContext(ctx)
 	if err != nil {
 		return err
 	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
+	for _, org := range orgs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(org, c.WithOrg(org.Id)); err != nil {
+			return err
+		}
 	}
-	return err
+	return nil
 }
@@ -1,8 +1,13 @@
 package gapi
 
 import (
-	"github.com/gobs/pretty"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/gobs/pretty"
 )
 
 const (
@@ -101,3 +106,61 @@ func TestDeleteOrg(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestForEachOrg(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/orgs" {
+			if r.Header.Get("X-Grafana-Org-Id") != "" {
+				t.Error("listing the orgs should not be scoped to an org")
+			}
+			fmt.Fprint(w, getOrgsJSON)
+			return
+		}
+		fmt.Fprintf(w, `[{"id":1,"uid":"org-%s","title":"Folder"}]`, r.Header.Get("X-Grafana-Org-Id"))
+	}))
+	defer server.Close()
+
+	client, err := New("my-key", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uids := []string{}
+	err = client.ForEachOrg(func(org Org, scoped *Client) error {
+		if scoped.OrgID() != org.Id {
+			t.Errorf("expected client scoped to org %d, got %d", org.Id, scoped.OrgID())
+		}
+		folders, err := scoped.Folders()
+		if err != nil {
+			return err
+		}
+		uids = append(uids, folders[0].Uid)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(uids) != 2 || uids[0] != "org-1" || uids[1] != "org-2" {
+		t.Errorf("expected requests scoped to org 1 and 2, got %v", uids)
+	}
+	if client.OrgID() != 0 {
+		t.Error("WithOrg should not modify the original client")
+	}
+}
+
+func TestForEachOrgStopsOnError(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getOrgsJSON)
+	defer server.Close()
+
+	calls := 0
+	stop := errors.New("stop")
+	err := client.ForEachOrg(func(org Org, scoped *Client) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("expected to stop after the first error, got %v after %d calls", err, calls)
+	}
+	rec.expect("GET", "/api/orgs")
+}
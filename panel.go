@@ -1,7 +1,7 @@
 package gapi
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"image/png"
 	"log"
@@ -184,22 +184,30 @@ func (c *Client) ExportPanelAsImage(
 	dashboardVars map[string][]string,
 	timeZone string,
 	output string) error {
+	return c.ExportPanelAsImageContext(context.Background(), dashboardID, orgID, panelID, timeRange, exportSize, dashboardVars, timeZone, output)
+}
+
+// ExportPanelAsImageContext is like ExportPanelAsImage but takes a context for cancellation and deadlines.
+func (c *Client) ExportPanelAsImageContext(
+	ctx context.Context,
+	dashboardID string,
+	orgID int64,
+	panelID int64,
+	timeRange TimeRange,
+	exportSize GrafanaPanelExportSize,
+	dashboardVars map[string][]string,
+	timeZone string,
+	output string) error {
 
 	renderURL, err := buildRenderURL(dashboardID, orgID, panelID, timeRange, exportSize, dashboardVars, timeZone)
 	if err != nil {
 		return err
 	}
-	req, err := c.newRequest("GET", renderURL, nil, nil)
+	resp, err := c.do(ctx, "GET", renderURL, nil, nil)
 	if err != nil {
 		return err
 	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
-	}
+	defer resp.Body.Close()
 	//myImage := image.NewRGBA(image.Rect(0, 0, 100, 200))
 	image, err := png.Decode(resp.Body)
 	if err != nil {
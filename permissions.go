@@ -0,0 +1,182 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Permission is the level of access granted by a PermissionItem
+type Permission int64
+
+const (
+	PermissionView  Permission = 1
+	PermissionEdit  Permission = 2
+	PermissionAdmin Permission = 4
+)
+
+// PermissionItem is an entry of a folder or dashboard ACL, exactly one of
+// Role, TeamID and UserID identifies who is granted the permission
+type PermissionItem struct {
+	Role       string     `json:"role,omitempty"`
+	TeamID     int64      `json:"teamId,omitempty"`
+	UserID     int64      `json:"userId,omitempty"`
+	Permission Permission `json:"permission"`
+
+	// read only, returned by Grafana
+	UserLogin string `json:"userLogin,omitempty"`
+	Team      string `json:"team,omitempty"`
+	Inherited bool   `json:"inherited,omitempty"`
+}
+
+func (p PermissionItem) sameGrantee(other PermissionItem) bool {
+	return p.Role == other.Role && p.TeamID == other.TeamID && p.UserID == other.UserID
+}
+
+// permissionsPayload builds the update request, inherited items belong to
+// the parent folder and can't be part of it
+func permissionsPayload(items []PermissionItem) ([]byte, error) {
+	payload := struct {
+		Items []PermissionItem `json:"items"`
+	}{Items: make([]PermissionItem, 0, len(items))}
+	for _, item := range items {
+		if item.Inherited {
+			continue
+		}
+		payload.Items = append(payload.Items, PermissionItem{
+			Role:       item.Role,
+			TeamID:     item.TeamID,
+			UserID:     item.UserID,
+			Permission: item.Permission,
+		})
+	}
+	return json.Marshal(payload)
+}
+
+// withPermission replaces the entry of the grantee of item or appends item
+func withPermission(items []PermissionItem, item PermissionItem) []PermissionItem {
+	result := withoutPermission(items, item)
+	return append(result, item)
+}
+
+// withoutPermission removes the entry of the grantee of item
+func withoutPermission(items []PermissionItem, item PermissionItem) []PermissionItem {
+	result := make([]PermissionItem, 0, len(items)+1)
+	for _, existing := range items {
+		if existing.Inherited || !existing.sameGrantee(item) {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
+
+// FolderPermissions returns the ACL of a folder
+func (c *Client) FolderPermissions(uid string) ([]PermissionItem, error) {
+	return c.FolderPermissionsContext(context.Background(), uid)
+}
+
+// FolderPermissionsContext is like FolderPermissions but takes a context for cancellation and deadlines.
+func (c *Client) FolderPermissionsContext(ctx context.Context, uid string) ([]PermissionItem, error) {
+	items := make([]PermissionItem, 0)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/folders/%s/permissions", uid), nil, nil, &items)
+	return items, err
+}
+
+// UpdateFolderPermissions replaces the ACL of a folder with items
+func (c *Client) UpdateFolderPermissions(uid string, items []PermissionItem) error {
+	return c.UpdateFolderPermissionsContext(context.Background(), uid, items)
+}
+
+// UpdateFolderPermissionsContext is like UpdateFolderPermissions but takes a context for cancellation and deadlines.
+func (c *Client) UpdateFolderPermissionsContext(ctx context.Context, uid string, items []PermissionItem) error {
+	data, err := permissionsPayload(items)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "POST", fmt.Sprintf("/api/folders/%s/permissions", uid), nil, bytes.NewBuffer(data), nil)
+}
+
+// AddFolderPermission grants item to its grantee, keeping the rest of the ACL
+func (c *Client) AddFolderPermission(uid string, item PermissionItem) error {
+	return c.AddFolderPermissionContext(context.Background(), uid, item)
+}
+
+// AddFolderPermissionContext is like AddFolderPermission but takes a context for cancellation and deadlines.
+func (c *Client) AddFolderPermissionContext(ctx context.Context, uid string, item PermissionItem) error {
+	items, err := c.FolderPermissionsContext(ctx, uid)
+	if err != nil {
+		return err
+	}
+	return c.UpdateFolderPermissionsContext(ctx, uid, withPermission(items, item))
+}
+
+// RemoveFolderPermission removes the entry of the grantee of item, keeping the rest of the ACL
+func (c *Client) RemoveFolderPermission(uid string, item PermissionItem) error {
+	return c.RemoveFolderPermissionContext(context.Background(), uid, item)
+}
+
+// RemoveFolderPermissionContext is like RemoveFolderPermission but takes a context for cancellation and deadlines.
+func (c *Client) RemoveFolderPermissionContext(ctx context.Context, uid string, item PermissionItem) error {
+	items, err := c.FolderPermissionsContext(ctx, uid)
+	if err != nil {
+		return err
+	}
+	return c.UpdateFolderPermissionsContext(ctx, uid, withoutPermission(items, item))
+}
+
+// DashboardPermissions returns the ACL of a dashboard, including the
+// entries inherited from its folder
+func (c *Client) DashboardPermissions(id int64) ([]PermissionItem, error) {
+	return c.DashboardPermissionsContext(context.Background(), id)
+}
+
+// DashboardPermissionsContext is like DashboardPermissions but takes a context for cancellation and deadlines.
+func (c *Client) DashboardPermissionsContext(ctx context.Context, id int64) ([]PermissionItem, error) {
+	items := make([]PermissionItem, 0)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/dashboards/id/%d/permissions", id), nil, nil, &items)
+	return items, err
+}
+
+// UpdateDashboardPermissions replaces the ACL of a dashboard with items,
+// inherited entries are skipped
+func (c *Client) UpdateDashboardPermissions(id int64, items []PermissionItem) error {
+	return c.UpdateDashboardPermissionsContext(context.Background(), id, items)
+}
+
+// UpdateDashboardPermissionsContext is like UpdateDashboardPermissions but takes a context for cancellation and deadlines.
+func (c *Client) UpdateDashboardPermissionsContext(ctx context.Context, id int64, items []PermissionItem) error {
+	data, err := permissionsPayload(items)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "POST", fmt.Sprintf("/api/dashboards/id/%d/permissions", id), nil, bytes.NewBuffer(data), nil)
+}
+
+// AddDashboardPermission grants item to its grantee, keeping the rest of the ACL
+func (c *Client) AddDashboardPermission(id int64, item PermissionItem) error {
+	return c.AddDashboardPermissionContext(context.Background(), id, item)
+}
+
+// AddDashboardPermissionContext is like AddDashboardPermission but takes a context for cancellation and deadlines.
+func (c *Client) AddDashboardPermissionContext(ctx context.Context, id int64, item PermissionItem) error {
+	items, err := c.DashboardPermissionsContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.UpdateDashboardPermissionsContext(ctx, id, withPermission(items, item))
+}
+
+// RemoveDashboardPermission removes the entry of the grantee of item, keeping the rest of the ACL
+func (c *Client) RemoveDashboardPermission(id int64, item PermissionItem) error {
+	return c.RemoveDashboardPermissionContext(context.Background(), id, item)
+}
+
+// RemoveDashboardPermissionContext is like RemoveDashboardPermission but takes a context for cancellation and deadlines.
+func (c *Client) RemoveDashboardPermissionContext(ctx context.Context, id int64, item PermissionItem) error {
+	items, err := c.DashboardPermissionsContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.UpdateDashboardPermissionsContext(ctx, id, withoutPermission(items, item))
+}
@@ -0,0 +1,193 @@
+package gapi
+
+import (
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	getFolderPermissionsJSON = `
+[
+  {
+    "id": 1,
+    "folderId": -1,
+    "created": "2017-06-20T02:00:00+02:00",
+    "updated": "2017-06-20T02:00:00+02:00",
+    "userId": 0,
+    "userLogin": "",
+    "userEmail": "",
+    "teamId": 0,
+    "team": "",
+    "role": "Viewer",
+    "permission": 1,
+    "permissionName": "View",
+    "uid": "nErXDvCkzz",
+    "title": "",
+    "slug": "",
+    "isFolder": true,
+    "url": ""
+  },
+  {
+    "id": 2,
+    "folderId": -1,
+    "created": "2017-06-20T02:00:00+02:00",
+    "updated": "2017-06-20T02:00:00+02:00",
+    "userId": 0,
+    "userLogin": "",
+    "userEmail": "",
+    "teamId": 1,
+    "team": "MyTestTeam",
+    "role": "",
+    "permission": 1,
+    "permissionName": "View",
+    "uid": "nErXDvCkzz",
+    "title": "",
+    "slug": "",
+    "isFolder": true,
+    "url": ""
+  }
+]
+`
+	getDashboardPermissionsJSON = `
+[
+  {
+    "dashboardId": 1,
+    "userId": 0,
+    "teamId": 0,
+    "role": "Editor",
+    "permission": 2,
+    "inherited": true
+  },
+  {
+    "dashboardId": 1,
+    "userId": 3,
+    "userLogin": "user1",
+    "teamId": 0,
+    "role": "",
+    "permission": 4,
+    "inherited": false
+  }
+]
+`
+	updatedPermissionsJSON = `{"message":"Folder permissions updated"}`
+)
+
+func TestFolderPermissions(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getFolderPermissionsJSON)
+	defer server.Close()
+
+	items, err := client.FolderPermissions("nErXDvCkzz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/folders/nErXDvCkzz/permissions")
+
+	t.Log(pretty.PrettyFormat(items))
+
+	expected := []PermissionItem{
+		{Role: "Viewer", Permission: PermissionView},
+		{TeamID: 1, Team: "MyTestTeam", Permission: PermissionView},
+	}
+	if len(items) != 2 || items[0] != expected[0] || items[1] != expected[1] {
+		t.Error("Not correctly parsing returned folder permissions.")
+	}
+}
+
+func TestUpdateFolderPermissions(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, updatedPermissionsJSON)
+	defer server.Close()
+	rec.respond("GET", "", 200, getFolderPermissionsJSON)
+
+	err := client.UpdateFolderPermissions("nErXDvCkzz", []PermissionItem{
+		{Role: "Editor", Permission: PermissionEdit},
+		{UserID: 3, UserLogin: "user1", Permission: PermissionAdmin},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"items":[{"role":"Editor","permission":2},{"userId":3,"permission":4}]}`
+	if body := rec.expect("POST", "/api/folders/nErXDvCkzz/permissions").Body; body != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestAddFolderPermission(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, updatedPermissionsJSON)
+	defer server.Close()
+	rec.respond("GET", "", 200, getFolderPermissionsJSON)
+
+	err := client.AddFolderPermission("nErXDvCkzz", PermissionItem{TeamID: 1, Permission: PermissionAdmin})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"items":[{"role":"Viewer","permission":1},{"teamId":1,"permission":4}]}`
+	if body := rec.expect("POST", "/api/folders/nErXDvCkzz/permissions").Body; body != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestRemoveFolderPermission(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, updatedPermissionsJSON)
+	defer server.Close()
+	rec.respond("GET", "", 200, getFolderPermissionsJSON)
+
+	err := client.RemoveFolderPermission("nErXDvCkzz", PermissionItem{Role: "Viewer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"items":[{"teamId":1,"permission":1}]}`
+	if body := rec.expect("POST", "/api/folders/nErXDvCkzz/permissions").Body; body != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestDashboardPermissions(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getDashboardPermissionsJSON)
+	defer server.Close()
+
+	items, err := client.DashboardPermissions(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/dashboards/id/1/permissions")
+
+	if len(items) != 2 || !items[0].Inherited || items[1].UserID != 3 || items[1].Permission != PermissionAdmin {
+		t.Error("Not correctly parsing returned dashboard permissions.")
+	}
+}
+
+func TestAddDashboardPermissionSkipsInherited(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, updatedPermissionsJSON)
+	defer server.Close()
+	rec.respond("GET", "", 200, getDashboardPermissionsJSON)
+
+	err := client.AddDashboardPermission(1, PermissionItem{Role: "Editor", Permission: PermissionEdit})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"items":[{"userId":3,"permission":4},{"role":"Editor","permission":2}]}`
+	if body := rec.expect("POST", "/api/dashboards/id/1/permissions").Body; body != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestRemoveDashboardPermission(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, updatedPermissionsJSON)
+	defer server.Close()
+	rec.respond("GET", "", 200, getDashboardPermissionsJSON)
+
+	err := client.RemoveDashboardPermission(1, PermissionItem{UserID: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"items":[]}`
+	if body := rec.expect("POST", "/api/dashboards/id/1/permissions").Body; body != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+}
@@ -2,10 +2,9 @@ package gapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
 )
 
 type Playlist struct {
@@ -25,96 +24,57 @@ type PlaylistItem struct {
 }
 
 func (c *Client) NewPlaylist(s *Playlist) (int64, error) {
-	data, err := json.Marshal(s)
-	if err != nil {
-		return 0, err
-	}
-	req, err := c.newRequest("POST", "/api/playlists", nil, bytes.NewBuffer(data))
-	if err != nil {
-		return 0, err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return 0, err
-	}
-	if resp.StatusCode != 200 {
-		return 0, errors.New(resp.Status)
-	}
+	return c.NewPlaylistContext(context.Background(), s)
+}
 
-	data, err = ioutil.ReadAll(resp.Body)
+// NewPlaylistContext is like NewPlaylist but takes a context for cancellation and deadlines.
+func (c *Client) NewPlaylistContext(ctx context.Context, s *Playlist) (int64, error) {
+	data, err := json.Marshal(s)
 	if err != nil {
 		return 0, err
 	}
-
 	result := struct {
 		Id int64 `json:"id"`
 	}{}
-	err = json.Unmarshal(data, &result)
+	err = c.request(ctx, "POST", "/api/playlists", nil, bytes.NewBuffer(data), &result)
 	return result.Id, err
 }
 
 func (c *Client) UpdatePlaylist(s *Playlist) error {
+	return c.UpdatePlaylistContext(context.Background(), s)
+}
+
+// UpdatePlaylistContext is like UpdatePlaylist but takes a context for cancellation and deadlines.
+func (c *Client) UpdatePlaylistContext(ctx context.Context, s *Playlist) error {
 	path := fmt.Sprintf("/api/playlists/%d", s.Id)
 	data, err := json.Marshal(s)
 	if err != nil {
 		return err
 	}
-	req, err := c.newRequest("PUT", path, nil, bytes.NewBuffer(data))
-	if err != nil {
-		return err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
-	}
-
-	return nil
+	return c.request(ctx, "PUT", path, nil, bytes.NewBuffer(data), nil)
 }
 
 func (c *Client) Playlist(id int64) (*Playlist, error) {
-	path := fmt.Sprintf("/api/playlists/%d", id)
-	req, err := c.newRequest("GET", path, nil, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	if resp.StatusCode != 200 {
-		return nil, errors.New(resp.Status)
-	}
+	return c.PlaylistContext(context.Background(), id)
+}
 
-	data, err := ioutil.ReadAll(resp.Body)
+// PlaylistContext is like Playlist but takes a context for cancellation and deadlines.
+func (c *Client) PlaylistContext(ctx context.Context, id int64) (*Playlist, error) {
+	path := fmt.Sprintf("/api/playlists/%d", id)
+	result := &Playlist{}
+	err := c.request(ctx, "GET", path, nil, nil, result)
 	if err != nil {
 		return nil, err
 	}
-
-	result := &Playlist{}
-	err = json.Unmarshal(data, &result)
 	return result, err
 }
 
 func (c *Client) DeletePlaylist(id int64) error {
-	path := fmt.Sprintf("/api/playlists/%d", id)
-	req, err := c.newRequest("DELETE", path, nil, nil)
-	if err != nil {
-		return err
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return err
-	}
-	if resp.StatusCode != 200 {
-		return errors.New(resp.Status)
-	}
+	return c.DeletePlaylistContext(context.Background(), id)
+}
 
-	return nil
+// DeletePlaylistContext is like DeletePlaylist but takes a context for cancellation and deadlines.
+func (c *Client) DeletePlaylistContext(ctx context.Context, id int64) error {
+	path := fmt.Sprintf("/api/playlists/%d", id)
+	return c.request(ctx, "DELETE", path, nil, nil, nil)
 }
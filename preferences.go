@@ -0,0 +1,61 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// Preferences represents the UI preferences of an org, a team or a user.
+// Empty fields are not sent, a patch only changes the fields that are set.
+type Preferences struct {
+	Theme            string `json:"theme,omitempty"`
+	HomeDashboardID  int64  `json:"homeDashboardId,omitempty"`
+	HomeDashboardUID string `json:"homeDashboardUID,omitempty"`
+	Timezone         string `json:"timezone,omitempty"`
+	// WeekStart is the first day of the week, e.g. monday or sunday
+	WeekStart string `json:"weekStart,omitempty"`
+}
+
+// OrgPreferences returns the preferences of the current org
+func (c *Client) OrgPreferences() (*Preferences, error) {
+	return c.OrgPreferencesContext(context.Background())
+}
+
+// OrgPreferencesContext is like OrgPreferences but takes a context for cancellation and deadlines.
+func (c *Client) OrgPreferencesContext(ctx context.Context) (*Preferences, error) {
+	preferences := &Preferences{}
+	err := c.request(ctx, "GET", "/api/org/preferences", nil, nil, preferences)
+	if err != nil {
+		return nil, err
+	}
+	return preferences, err
+}
+
+// UpdateOrgPreferences replaces the preferences of the current org
+func (c *Client) UpdateOrgPreferences(preferences Preferences) error {
+	return c.UpdateOrgPreferencesContext(context.Background(), preferences)
+}
+
+// UpdateOrgPreferencesContext is like UpdateOrgPreferences but takes a context for cancellation and deadlines.
+func (c *Client) UpdateOrgPreferencesContext(ctx context.Context, preferences Preferences) error {
+	return c.sendPreferences(ctx, "PUT", "/api/org/preferences", preferences)
+}
+
+// PatchOrgPreferences changes the preferences of the current org that are set in preferences
+func (c *Client) PatchOrgPreferences(preferences Preferences) error {
+	return c.PatchOrgPreferencesContext(context.Background(), preferences)
+}
+
+// PatchOrgPreferencesContext is like PatchOrgPreferences but takes a context for cancellation and deadlines.
+func (c *Client) PatchOrgPreferencesContext(ctx context.Context, preferences Preferences) error {
+	return c.sendPreferences(ctx, "PATCH", "/api/org/preferences", preferences)
+}
+
+func (c *Client) sendPreferences(ctx context.Context, method, path string, preferences Preferences) error {
+	data, err := json.Marshal(preferences)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, method, path, nil, bytes.NewBuffer(data), nil)
+}
@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"testing"
+)
+
+const (
+	getOrgPreferencesJSON = `{"theme":"light","homeDashboardId":12,"homeDashboardUID":"nErXDvCkzz","timezone":"browser","weekStart":"monday"}`
+)
+
+func TestOrgPreferences(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getOrgPreferencesJSON)
+	defer server.Close()
+
+	preferences, err := client.OrgPreferences()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/org/preferences")
+
+	expected := Preferences{Theme: "light", HomeDashboardID: 12, HomeDashboardUID: "nErXDvCkzz", Timezone: "browser", WeekStart: "monday"}
+	if *preferences != expected {
+		t.Error("Not correctly parsing returned org preferences.")
+	}
+}
+
+func TestUpdateOrgPreferences(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getOrgPreferencesJSON)
+	defer server.Close()
+
+	if err := client.UpdateOrgPreferences(Preferences{Theme: "dark", HomeDashboardUID: "nErXDvCkzz"}); err != nil {
+		t.Fatal(err)
+	}
+	if body := rec.expect("PUT", "/api/org/preferences").Body; body != `{"theme":"dark","homeDashboardUID":"nErXDvCkzz"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestPatchPreferences(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getOrgPreferencesJSON)
+	defer server.Close()
+
+	if err := client.PatchOrgPreferences(Preferences{WeekStart: "sunday"}); err != nil {
+		t.Fatal(err)
+	}
+	if body := rec.expect("PATCH", "/api/org/preferences").Body; body != `{"weekStart":"sunday"}` {
+		t.Errorf("expected only the set fields to be patched, got %s", body)
+	}
+
+	if err := client.PatchTeamPreferences(3, Preferences{Timezone: "utc"}); err != nil {
+		t.Fatal(err)
+	}
+	if body := rec.expect("PATCH", "/api/teams/3/preferences").Body; body != `{"timezone":"utc"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+
+	if err := client.PatchCurrentUserPreferences(Preferences{Theme: "light"}); err != nil {
+		t.Fatal(err)
+	}
+	if body := rec.expect("PATCH", "/api/user/preferences").Body; body != `{"theme":"light"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+}
@@ -0,0 +1,192 @@
+package gapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Result types of Prometheus queries
+const (
+	PrometheusResultVector = "vector"
+	PrometheusResultMatrix = "matrix"
+	PrometheusResultScalar = "scalar"
+	PrometheusResultString = "string"
+)
+
+// PrometheusQueryResult is the result of a Prometheus query, depending on
+// ResultType either Vector, Matrix or Scalar is set. Scalar also holds
+// the result of the string type in its Raw field.
+type PrometheusQueryResult struct {
+	ResultType string
+	Vector     []PrometheusSample
+	Matrix     []PrometheusSeries
+	Scalar     *PrometheusValue
+	Warnings   []string
+}
+
+// UnmarshalJSON decodes the result according to its resultType
+func (r *PrometheusQueryResult) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = PrometheusQueryResult{ResultType: raw.ResultType}
+	switch raw.ResultType {
+	case PrometheusResultVector:
+		return json.Unmarshal(raw.Result, &r.Vector)
+	case PrometheusResultMatrix:
+		return json.Unmarshal(raw.Result, &r.Matrix)
+	case PrometheusResultScalar, PrometheusResultString:
+		r.Scalar = &PrometheusValue{}
+		return json.Unmarshal(raw.Result, r.Scalar)
+	}
+	return fmt.Errorf("unknown prometheus result type %q", raw.ResultType)
+}
+
+// PrometheusSample is a single value of a series
+type PrometheusSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  PrometheusValue   `json:"value"`
+}
+
+// PrometheusSeries are the values of a series over a time range
+type PrometheusSeries struct {
+	Metric map[string]string `json:"metric"`
+	Values []PrometheusValue `json:"values"`
+}
+
+// PrometheusValue is a value at a point in time, encoded as [time, "value"].
+// Value is NaN if Raw is not a number.
+type PrometheusValue struct {
+	Time  time.Time
+	Value float64
+	Raw   string
+}
+
+// UnmarshalJSON decodes the value from a [time, "value"] tuple
+func (v *PrometheusValue) UnmarshalJSON(data []byte) error {
+	var tuple []interface{}
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+	if len(tuple) != 2 {
+		return fmt.Errorf("invalid prometheus value %s", data)
+	}
+	ts, ok := tuple[0].(float64)
+	raw, ok2 := tuple[1].(string)
+	if !ok || !ok2 {
+		return fmt.Errorf("invalid prometheus value %s", data)
+	}
+	sec, frac := math.Modf(ts)
+	v.Time = time.Unix(int64(sec), int64(math.Round(frac*1000))*int64(time.Millisecond))
+	v.Raw = raw
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		value = math.NaN()
+	}
+	v.Value = value
+	return nil
+}
+
+// PrometheusQuery evaluates an instant query at ts through the proxy of the
+// Prometheus data source with the given UID, a zero ts evaluates it now
+func (c *Client) PrometheusQuery(uid, query string, ts time.Time) (*PrometheusQueryResult, error) {
+	return c.PrometheusQueryContext(context.Background(), uid, query, ts)
+}
+
+// PrometheusQueryContext is like PrometheusQuery but takes a context for cancellation and deadlines.
+func (c *Client) PrometheusQueryContext(ctx context.Context, uid, query string, ts time.Time) (*PrometheusQueryResult, error) {
+	params := url.Values{}
+	params.Add("query", query)
+	if !ts.IsZero() {
+		params.Add("time", prometheusTime(ts))
+	}
+	result := &PrometheusQueryResult{}
+	err := c.prometheusRequest(ctx, uid, "api/v1/query", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// PrometheusQueryRange evaluates a query over a time range through the proxy
+// of the Prometheus data source with the given UID
+func (c *Client) PrometheusQueryRange(uid, query string, start, end time.Time, step time.Duration) (*PrometheusQueryResult, error) {
+	return c.PrometheusQueryRangeContext(context.Background(), uid, query, start, end, step)
+}
+
+// PrometheusQueryRangeContext is like PrometheusQueryRange but takes a context for cancellation and deadlines.
+func (c *Client) PrometheusQueryRangeContext(ctx context.Context, uid, query string, start, end time.Time, step time.Duration) (*PrometheusQueryResult, error) {
+	params := url.Values{}
+	params.Add("query", query)
+	params.Add("start", prometheusTime(start))
+	params.Add("end", prometheusTime(end))
+	params.Add("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+	result := &PrometheusQueryResult{}
+	err := c.prometheusRequest(ctx, uid, "api/v1/query_range", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// PrometheusLabels lists the label names of the Prometheus data source with the given UID
+func (c *Client) PrometheusLabels(uid string) ([]string, error) {
+	return c.PrometheusLabelsContext(context.Background(), uid)
+}
+
+// PrometheusLabelsContext is like PrometheusLabels but takes a context for cancellation and deadlines.
+func (c *Client) PrometheusLabelsContext(ctx context.Context, uid string) ([]string, error) {
+	labels := make([]string, 0)
+	err := c.prometheusRequest(ctx, uid, "api/v1/labels", nil, &labels)
+	return labels, err
+}
+
+// PrometheusLabelValues lists the values of a label of the Prometheus data source with the given UID
+func (c *Client) PrometheusLabelValues(uid, label string) ([]string, error) {
+	return c.PrometheusLabelValuesContext(context.Background(), uid, label)
+}
+
+// PrometheusLabelValuesContext is like PrometheusLabelValues but takes a context for cancellation and deadlines.
+func (c *Client) PrometheusLabelValuesContext(ctx context.Context, uid, label string) ([]string, error) {
+	values := make([]string, 0)
+	err := c.prometheusRequest(ctx, uid, fmt.Sprintf("api/v1/label/%s/values", label), nil, &values)
+	return values, err
+}
+
+// prometheusRequest calls the Prometheus HTTP API through the data source
+// proxy and decodes the data of the response envelope into v
+func (c *Client) prometheusRequest(ctx context.Context, uid, path string, params url.Values, v interface{}) error {
+	envelope := struct {
+		Status    string          `json:"status"`
+		Data      json.RawMessage `json:"data"`
+		ErrorType string          `json:"errorType"`
+		Error     string          `json:"error"`
+		Warnings  []string        `json:"warnings"`
+	}{}
+	if err := c.request(ctx, "GET", dataSourceProxyPath(uid, path), params, nil, &envelope); err != nil {
+		return err
+	}
+	if envelope.Status != "success" {
+		return fmt.Errorf("prometheus query failed: %s: %s", envelope.ErrorType, envelope.Error)
+	}
+	if err := json.Unmarshal(envelope.Data, v); err != nil {
+		return err
+	}
+	if result, ok := v.(*PrometheusQueryResult); ok {
+		result.Warnings = envelope.Warnings
+	}
+	return nil
+}
+
+func prometheusTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', 3, 64)
+}
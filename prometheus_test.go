@@ -0,0 +1,182 @@
+package gapi
+
+import (
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	prometheusVectorJSON = `
+{
+  "status": "success",
+  "data": {
+    "resultType": "vector",
+    "result": [
+      {"metric": {"__name__": "up", "job": "prometheus"}, "value": [1435781451.781, "1"]},
+      {"metric": {"__name__": "up", "job": "node"}, "value": [1435781451.781, "NaN"]}
+    ]
+  },
+  "warnings": ["query hit the sample limit"]
+}
+`
+	prometheusMatrixJSON = `
+{
+  "status": "success",
+  "data": {
+    "resultType": "matrix",
+    "result": [
+      {"metric": {"__name__": "up", "job": "prometheus"}, "values": [[1435781430.781, "1"], [1435781445.781, "0"]]}
+    ]
+  }
+}
+`
+	prometheusLabelsJSON = `{"status": "success", "data": ["__name__", "instance", "job"]}`
+)
+
+func TestDataSourceProxy(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+	defer server.Close()
+
+	resp, err := client.DataSourceProxy("prometheus", "GET", "/api/v1/query", map[string][]string{"query": {"up("}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	if q := rec.expect("GET", "/api/datasources/proxy/uid/prometheus/api/v1/query").Query; q.Get("query") != "up(" || string(body) == "" {
+		t.Errorf("expected the query to be proxied, got %v", q)
+	}
+}
+
+func TestDataSourceProxyWithHeader(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{}`)
+	defer server.Close()
+
+	resp, err := client.DataSourceProxy("elasticsearch", "POST", "_msearch", nil, strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if contentType := rec.expect("POST", "/api/datasources/proxy/uid/elasticsearch/_msearch").Header.Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("expected the JSON content type by default, got %s", contentType)
+	}
+
+	form := url.Values{"query": {"up"}}
+	header := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+	resp, err = client.DataSourceProxyWithHeader("prometheus", "POST", "api/v1/query", nil, header, strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	req := rec.expect("POST", "/api/datasources/proxy/uid/prometheus/api/v1/query")
+	if req.Header.Get("Content-Type") != "application/x-www-form-urlencoded" || req.Body != "query=up" {
+		t.Errorf("expected the form to be sent with its content type, got %s with body %s", req.Header.Get("Content-Type"), req.Body)
+	}
+}
+
+func TestDataSourceProxyWithHeaderKeepsClientHeaders(t *testing.T) {
+	server, _, rec := gapiRecorderTestTools(t, 200, `{}`)
+	defer server.Close()
+
+	client, err := NewClient(server.URL, WithAPIKey("my-key"), WithOrgID(2), WithHeader("X-Custom", "foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"Authorization", "x-grafana-org-id", "Cookie"} {
+		if _, err := client.DataSourceProxyWithHeader("prometheus", "GET", "api/v1/labels", nil, http.Header{key: {"other"}}, nil); err == nil {
+			t.Errorf("expected the %s header to be rejected", key)
+		}
+	}
+	if len(rec.all()) != 0 {
+		t.Fatal("expected no request with a rejected header to be sent")
+	}
+
+	resp, err := client.DataSourceProxyWithHeader("prometheus", "GET", "api/v1/labels", nil, http.Header{"X-Custom": {"bar"}, "X-Scope-Orgid": {"tenant"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	h := rec.last().Header
+	if h.Get("Authorization") != "Bearer my-key" || h.Get("X-Grafana-Org-Id") != "2" || h.Get("X-Custom") != "foo" || h.Get("X-Scope-Orgid") != "tenant" {
+		t.Errorf("expected the client headers to be kept next to the passed ones, got %v", h)
+	}
+}
+
+func TestPrometheusQuery(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, prometheusVectorJSON)
+	defer server.Close()
+
+	result, err := client.PrometheusQuery("prometheus", "up", time.Unix(1435781451, 781000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(pretty.PrettyFormat(result))
+
+	if q := rec.expect("GET", "/api/datasources/proxy/uid/prometheus/api/v1/query").Query; q.Get("query") != "up" || q.Get("time") != "1435781451.781" {
+		t.Errorf("unexpected query parameters %v", q)
+	}
+	if result.ResultType != PrometheusResultVector || len(result.Vector) != 2 || len(result.Warnings) != 1 {
+		t.Fatal("Not correctly parsing returned prometheus vector.")
+	}
+	sample := result.Vector[0]
+	if sample.Metric["job"] != "prometheus" || sample.Value.Value != 1 || !sample.Value.Time.Equal(time.Unix(1435781451, 781000000)) {
+		t.Errorf("Not correctly parsing the prometheus sample: %+v", sample)
+	}
+	if !math.IsNaN(result.Vector[1].Value.Value) || result.Vector[1].Value.Raw != "NaN" {
+		t.Error("Not correctly parsing a NaN prometheus value.")
+	}
+}
+
+func TestPrometheusQueryRange(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, prometheusMatrixJSON)
+	defer server.Close()
+
+	end := time.Unix(1435781460, 0)
+	result, err := client.PrometheusQueryRange("prometheus", "up", end.Add(-30*time.Second), end, 15*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q := rec.expect("GET", "/api/datasources/proxy/uid/prometheus/api/v1/query_range").Query; q.Get("start") != "1435781430.000" || q.Get("end") != "1435781460.000" || q.Get("step") != "15" {
+		t.Errorf("unexpected query parameters %v", q)
+	}
+	if len(result.Matrix) != 1 || len(result.Matrix[0].Values) != 2 || result.Matrix[0].Values[1].Value != 0 {
+		t.Error("Not correctly parsing returned prometheus matrix.")
+	}
+}
+
+func TestPrometheusLabels(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, prometheusLabelsJSON)
+	defer server.Close()
+
+	labels, err := client.PrometheusLabels("prometheus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/datasources/proxy/uid/prometheus/api/v1/labels")
+
+	if len(labels) != 3 || labels[2] != "job" {
+		t.Error("Not correctly parsing returned prometheus labels.")
+	}
+}
+
+func TestPrometheusQueryError(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 400, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+	defer server.Close()
+
+	_, err := client.PrometheusQuery("prometheus", "up(", time.Time{})
+	if !IsBadRequest(err) {
+		t.Errorf("expected a bad request error, got %v", err)
+	}
+	rec.expect("GET", "/api/datasources/proxy/uid/prometheus/api/v1/query")
+}
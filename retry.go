@@ -0,0 +1,139 @@
+package gapi
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMinBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff = 10 * time.Second
+	defaultRetryMaxAfter   = time.Minute
+)
+
+// RetryPolicy configures how requests failing with a transport error or
+// a retryable status code are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one,
+	// values below 2 disable retries
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry, it doubles with every
+	// further attempt up to MaxBackoff. A random jitter of up to half the
+	// wait is subtracted
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxRetryAfter caps the wait requested by a Retry-After header,
+	// defaults to one minute
+	MaxRetryAfter time.Duration
+	// StatusCodes are the response codes to retry, defaults to 429, 502, 503 and 504
+	StatusCodes []int
+	// RetryNonIdempotent enables retries of POST and PATCH requests
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and the default backoff
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:   3,
+		MinBackoff:    defaultRetryMinBackoff,
+		MaxBackoff:    defaultRetryMaxBackoff,
+		MaxRetryAfter: defaultRetryMaxAfter,
+	}
+}
+
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+func (p *RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, resp *http.Response, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if !isIdempotent(method) && !p.RetryNonIdempotent {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	statusCodes := p.StatusCodes
+	if statusCodes == nil {
+		statusCodes = defaultRetryStatusCodes
+	}
+	for _, code := range statusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before the next attempt, a Retry-After header
+// sent by Grafana or a proxy in front of it takes precedence up to MaxRetryAfter
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			limit := p.MaxRetryAfter
+			if limit <= 0 {
+				limit = defaultRetryMaxAfter
+			}
+			if wait > limit {
+				wait = limit
+			}
+			return wait
+		}
+	}
+
+	min, max := p.MinBackoff, p.MaxBackoff
+	if min <= 0 {
+		min = defaultRetryMinBackoff
+	}
+	if max <= 0 {
+		max = defaultRetryMaxBackoff
+	}
+	wait := min
+	for i := 1; i < attempt && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		wait = max
+	}
+	if half := int64(wait / 2); half > 0 {
+		wait -= time.Duration(rand.Int63n(half))
+	}
+	return wait
+}
+
+// retryAfter parses a Retry-After header given in seconds or as HTTP date
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
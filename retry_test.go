@@ -0,0 +1,180 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gapiFlakyTestTools returns a server that answers the first failures requests
+// with the given status code and all further requests with 200 and body.
+// It records the body of every request it receives.
+func gapiFlakyTestTools(t *testing.T, failures, code int, body string, policy *RetryPolicy) (*httptest.Server, *Client, func() []string) {
+	var mu sync.Mutex
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, string(data))
+		attempt := len(received)
+		mu.Unlock()
+
+		if attempt <= failures {
+			w.WriteHeader(code)
+			return
+		}
+		w.WriteHeader(200)
+		fmt.Fprint(w, body)
+	}))
+
+	client, err := NewWithConfig("my-key", server.URL, ClientConfig{Retry: policy})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server, client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), received...)
+	}
+}
+
+func fastRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	server, client, received := gapiFlakyTestTools(t, 2, 503, getFoldersJSON, fastRetryPolicy())
+	defer server.Close()
+
+	folders, err := client.Folders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folders) != 1 {
+		t.Error("Not correctly parsing returned folders after retries.")
+	}
+	if attempts := len(received()); attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	server, client, received := gapiFlakyTestTools(t, 5, 502, getFoldersJSON, fastRetryPolicy())
+	defer server.Close()
+
+	_, err := client.Folders()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 502 {
+		t.Errorf("expected the last 502 response as error, got %v", err)
+	}
+	if attempts := len(received()); attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetrySkipsNonIdempotentRequest(t *testing.T) {
+	server, client, received := gapiFlakyTestTools(t, 1, 503, createdDataSourceJSON, fastRetryPolicy())
+	defer server.Close()
+
+	_, err := client.NewDataSource(&DataSource{Name: "foo"})
+	if err == nil {
+		t.Error("expected POST requests not to be retried by default")
+	}
+	if attempts := len(received()); attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestRetryReplaysRequestBody(t *testing.T) {
+	policy := fastRetryPolicy()
+	policy.RetryNonIdempotent = true
+	server, client, received := gapiFlakyTestTools(t, 2, 429, createdDataSourceJSON, policy)
+	defer server.Close()
+
+	id, err := client.NewDataSource(&DataSource{Name: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 1 {
+		t.Error("datasource creation response should return the created datasource ID")
+	}
+
+	bodies := received()
+	if len(bodies) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(bodies))
+	}
+	for _, body := range bodies {
+		if body == "" || body != bodies[0] {
+			t.Errorf("expected every attempt to send the same body, got %q", bodies)
+			break
+		}
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	policy := fastRetryPolicy()
+	policy.MinBackoff = time.Minute
+	policy.MaxBackoff = time.Minute
+	server, client, _ := gapiFlakyTestTools(t, 1, 503, getFoldersJSON, policy)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.FoldersContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2019, 9, 15, 12, 0, 0, 0, time.UTC)
+
+	if wait, ok := retryAfter("2", now); !ok || wait != 2*time.Second {
+		t.Errorf("expected 2s, got %s", wait)
+	}
+	if wait, ok := retryAfter("Sun, 15 Sep 2019 12:00:30 GMT", now); !ok || wait != 30*time.Second {
+		t.Errorf("expected 30s, got %s", wait)
+	}
+	if _, ok := retryAfter("soon", now); ok {
+		t.Error("expected an invalid Retry-After header to be ignored")
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	for attempt, max := range []time.Duration{100, 200, 300, 300} {
+		max *= time.Millisecond
+		wait := policy.backoff(attempt+1, nil)
+		if wait > max || wait < max/2 {
+			t.Errorf("attempt %d: expected a backoff between %s and %s, got %s", attempt+1, max/2, max, wait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if wait := policy.backoff(1, resp); wait != 3*time.Second {
+		t.Errorf("expected the Retry-After header to be honored, got %s", wait)
+	}
+
+	resp = &http.Response{Header: http.Header{"Retry-After": []string{"86400"}}}
+	if wait := policy.backoff(1, resp); wait != defaultRetryMaxAfter {
+		t.Errorf("expected the Retry-After header to be capped at %s, got %s", defaultRetryMaxAfter, wait)
+	}
+
+	policy.MaxRetryAfter = 2 * time.Second
+	if wait := policy.backoff(1, resp); wait != 2*time.Second {
+		t.Errorf("expected the Retry-After header to be capped at MaxRetryAfter, got %s", wait)
+	}
+}
@@ -0,0 +1,144 @@
+package gapi
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// SearchTypeDashboard limits a search to dashboards
+	SearchTypeDashboard = "dash-db"
+	// SearchTypeFolder limits a search to folders
+	SearchTypeFolder = "dash-folder"
+
+	defaultSearchLimit = 1000
+)
+
+// SearchQuery holds the parameters of the search API, empty fields are not sent
+type SearchQuery struct {
+	Query         string
+	Tags          []string
+	Type          string
+	DashboardIDs  []int64
+	DashboardUIDs []string
+	FolderIDs     []int64
+	FolderUIDs    []string
+	Starred       bool
+	Limit         int64
+	Page          int64
+}
+
+func (q SearchQuery) values() url.Values {
+	params := url.Values{}
+	if q.Query != "" {
+		params.Add("query", q.Query)
+	}
+	for _, tag := range q.Tags {
+		params.Add("tag", tag)
+	}
+	if q.Type != "" {
+		params.Add("type", q.Type)
+	}
+	for _, id := range q.DashboardIDs {
+		params.Add("dashboardIds", strconv.FormatInt(id, 10))
+	}
+	for _, uid := range q.DashboardUIDs {
+		params.Add("dashboardUIDs", uid)
+	}
+	for _, id := range q.FolderIDs {
+		params.Add("folderIds", strconv.FormatInt(id, 10))
+	}
+	for _, uid := range q.FolderUIDs {
+		params.Add("folderUIDs", uid)
+	}
+	if q.Starred {
+		params.Add("starred", "true")
+	}
+	if q.Limit > 0 {
+		params.Add("limit", strconv.FormatInt(q.Limit, 10))
+	}
+	if q.Page > 0 {
+		params.Add("page", strconv.FormatInt(q.Page, 10))
+	}
+	return params
+}
+
+// SearchHit is a dashboard or folder returned by the search API
+type SearchHit struct {
+	ID          int64    `json:"id"`
+	UID         string   `json:"uid"`
+	Title       string   `json:"title"`
+	URI         string   `json:"uri"`
+	URL         string   `json:"url"`
+	Slug        string   `json:"slug"`
+	Type        string   `json:"type"`
+	Tags        []string `json:"tags"`
+	IsStarred   bool     `json:"isStarred"`
+	FolderID    int64    `json:"folderId"`
+	FolderUID   string   `json:"folderUid"`
+	FolderTitle string   `json:"folderTitle"`
+	FolderURL   string   `json:"folderUrl"`
+}
+
+// Search searches dashboards and folders, it returns a single page of results
+func (c *Client) Search(query SearchQuery) ([]SearchHit, error) {
+	return c.SearchContext(context.Background(), query)
+}
+
+// SearchContext is like Search but takes a context for cancellation and deadlines.
+func (c *Client) SearchContext(ctx context.Context, query SearchQuery) ([]SearchHit, error) {
+	hits := make([]SearchHit, 0)
+	err := c.request(ctx, "GET", "/api/search", query.values(), nil, &hits)
+	return hits, err
+}
+
+// SearchIterator pages through all results of a search
+//
+//	it := client.NewSearchIterator(gapi.SearchQuery{Tags: []string{"prod"}})
+//	for it.Next() {
+//		hit := it.Hit()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type SearchIterator struct {
+	pager
+	hits []SearchHit
+}
+
+// NewSearchIterator returns an iterator over all results of the query,
+// starting at query.Page. query.Limit is the page size, 1000 if not set
+func (c *Client) NewSearchIterator(query SearchQuery) *SearchIterator {
+	return c.NewSearchIteratorContext(context.Background(), query)
+}
+
+// NewSearchIteratorContext is like NewSearchIterator but takes a context for cancellation and deadlines.
+func (c *Client) NewSearchIteratorContext(ctx context.Context, query SearchQuery) *SearchIterator {
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchLimit
+	}
+	it := &SearchIterator{}
+	it.pager = newPager(query.Page, query.Limit, func(page int64) (int, error) {
+		query.Page = page
+		hits, err := c.SearchContext(ctx, query)
+		it.hits = hits
+		return len(hits), err
+	})
+	return it
+}
+
+// Next advances to the next hit, it returns false when all hits have been
+// read or an error occurred
+func (it *SearchIterator) Next() bool {
+	return it.next()
+}
+
+// Hit returns the current hit
+func (it *SearchIterator) Hit() SearchHit {
+	return it.hits[it.i]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *SearchIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,167 @@
+package gapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	searchJSON = `
+[
+  {
+    "id": 163,
+    "uid": "000000163",
+    "title": "Folder",
+    "url": "/dashboards/f/000000163/folder",
+    "type": "dash-folder",
+    "tags": [],
+    "isStarred": false,
+    "uri": "db/folder"
+  },
+  {
+    "id": 1,
+    "uid": "cIBgcSjkk",
+    "title": "Production Overview",
+    "url": "/d/cIBgcSjkk/production-overview",
+    "type": "dash-db",
+    "tags": ["prod"],
+    "isStarred": true,
+    "uri": "db/production-overview",
+    "folderId": 163,
+    "folderUid": "000000163",
+    "folderTitle": "Folder",
+    "folderUrl": "/dashboards/f/000000163/folder"
+  }
+]
+`
+)
+
+func TestSearch(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, searchJSON)
+	defer server.Close()
+
+	hits, err := client.Search(SearchQuery{
+		Query:         "Production",
+		Tags:          []string{"prod", "team-a"},
+		Type:          SearchTypeDashboard,
+		DashboardUIDs: []string{"cIBgcSjkk"},
+		FolderIDs:     []int64{0, 163},
+		FolderUIDs:    []string{"000000163"},
+		Starred:       true,
+		Limit:         10,
+		Page:          2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(pretty.PrettyFormat(hits))
+
+	expected := url.Values{
+		"query":         {"Production"},
+		"tag":           {"prod", "team-a"},
+		"type":          {"dash-db"},
+		"dashboardUIDs": {"cIBgcSjkk"},
+		"folderIds":     {"0", "163"},
+		"folderUIDs":    {"000000163"},
+		"starred":       {"true"},
+		"limit":         {"10"},
+		"page":          {"2"},
+	}
+	if q := rec.expect("GET", "/api/search").Query; !reflect.DeepEqual(q, expected) {
+		t.Errorf("expected query %v, got %v", expected, q)
+	}
+
+	if len(hits) != 2 || hits[1].FolderUID != "000000163" || !hits[1].IsStarred || hits[1].Tags[0] != "prod" {
+		t.Error("Not correctly parsing returned search hits.")
+	}
+}
+
+func TestSearchIterator(t *testing.T) {
+	all := make([]SearchHit, 5)
+	for i := range all {
+		all[i] = SearchHit{ID: int64(i + 1), Type: SearchTypeDashboard}
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start, end := (page-1)*limit, page*limit
+		if start > len(all) {
+			start = len(all)
+		}
+		if end > len(all) {
+			end = len(all)
+		}
+		json.NewEncoder(w).Encode(all[start:end])
+	}))
+	defer server.Close()
+
+	client, err := New("my-key", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []int64{}
+	it := client.NewSearchIterator(SearchQuery{Type: SearchTypeDashboard, Limit: 2})
+	for it.Next() {
+		ids = append(ids, it.Hit().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(ids, []int64{1, 2, 3, 4, 5}) {
+		t.Errorf("expected all hits, got %v", ids)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 pages to be requested, got %d", requests)
+	}
+}
+
+func TestSearchIteratorError(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 500, `{"message":"Search failed"}`)
+	defer server.Close()
+
+	it := client.NewSearchIterator(SearchQuery{})
+	if it.Next() {
+		t.Error("expected no hits")
+	}
+	if it.Err() == nil {
+		t.Error("expected the error to be returned")
+	}
+	rec.expect("GET", "/api/search")
+}
+
+func TestSearchDashboard(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, searchJSON)
+	defer server.Close()
+
+	dashboards, err := client.SearchDashboard("Production", "163")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := rec.expect("GET", "/api/search").Query
+	if q.Get("type") != SearchTypeDashboard || q.Get("query") != "Production" || q.Get("folderIds") != "163" {
+		t.Errorf("unexpected query %v", q)
+	}
+	if len(dashboards) != 2 || dashboards[1].UID != "cIBgcSjkk" || !dashboards[1].Starred || dashboards[1].FolderID != 163 {
+		t.Error("Not correctly parsing returned dashboards.")
+	}
+
+	if _, err := client.SearchDashboard("", "0,163"); err != nil {
+		t.Fatal(err)
+	}
+	if q := rec.expect("GET", "/api/search").Query; q.Get("folderIds") != "0,163" {
+		t.Errorf("expected the folder ids to be passed as is, got %v", q)
+	}
+}
@@ -0,0 +1,190 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ServiceAccount represents a Grafana service account
+type ServiceAccount struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Login      string `json:"login"`
+	OrgID      int64  `json:"orgId"`
+	IsDisabled bool   `json:"isDisabled"`
+	Role       string `json:"role"`
+	Tokens     int64  `json:"tokens"`
+	AvatarURL  string `json:"avatarUrl,omitempty"`
+}
+
+// CreateServiceAccountRequest is the payload to create a service account
+type CreateServiceAccountRequest struct {
+	Name       string `json:"name"`
+	Role       string `json:"role,omitempty"`
+	IsDisabled bool   `json:"isDisabled,omitempty"`
+}
+
+// UpdateServiceAccountRequest is the payload to update a service account,
+// empty fields are left unchanged
+type UpdateServiceAccountRequest struct {
+	Name       string `json:"name,omitempty"`
+	Role       string `json:"role,omitempty"`
+	IsDisabled *bool  `json:"isDisabled,omitempty"`
+}
+
+// SearchServiceAccount is a page of service accounts returned by the search
+type SearchServiceAccount struct {
+	TotalCount      int64            `json:"totalCount"`
+	ServiceAccounts []ServiceAccount `json:"serviceAccounts"`
+	Page            int64            `json:"page"`
+	PerPage         int64            `json:"perPage"`
+}
+
+// ServiceAccountToken represents a token of a service account, the secret
+// is only returned once by NewServiceAccountToken
+type ServiceAccountToken struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Created    *time.Time `json:"created,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+	HasExpired bool       `json:"hasExpired"`
+}
+
+// CreateServiceAccountTokenRequest is the payload to create a service account
+// token, the token never expires if SecondsToLive is zero
+type CreateServiceAccountTokenRequest struct {
+	Name          string `json:"name"`
+	SecondsToLive int64  `json:"secondsToLive,omitempty"`
+}
+
+// CreateServiceAccountTokenResponse is the created service account token
+type CreateServiceAccountTokenResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Key is the secret to authenticate with, Grafana does not return it again
+	Key string `json:"key"`
+}
+
+// SearchServiceAccounts searches the service accounts of the current org, page starts at 1
+func (c *Client) SearchServiceAccounts(query string, page, perPage int64) (*SearchServiceAccount, error) {
+	return c.SearchServiceAccountsContext(context.Background(), query, page, perPage)
+}
+
+// SearchServiceAccountsContext is like SearchServiceAccounts but takes a context for cancellation and deadlines.
+func (c *Client) SearchServiceAccountsContext(ctx context.Context, query string, page, perPage int64) (*SearchServiceAccount, error) {
+	params := url.Values{}
+	params.Add("query", query)
+	if page > 0 {
+		params.Add("page", strconv.FormatInt(page, 10))
+	}
+	if perPage > 0 {
+		params.Add("perpage", strconv.FormatInt(perPage, 10))
+	}
+	result := &SearchServiceAccount{}
+	err := c.request(ctx, "GET", "/api/serviceaccounts/search", params, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+func (c *Client) ServiceAccount(id int64) (*ServiceAccount, error) {
+	return c.ServiceAccountContext(context.Background(), id)
+}
+
+// ServiceAccountContext is like ServiceAccount but takes a context for cancellation and deadlines.
+func (c *Client) ServiceAccountContext(ctx context.Context, id int64) (*ServiceAccount, error) {
+	result := &ServiceAccount{}
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/serviceaccounts/%d", id), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+func (c *Client) NewServiceAccount(request CreateServiceAccountRequest) (*ServiceAccount, error) {
+	return c.NewServiceAccountContext(context.Background(), request)
+}
+
+// NewServiceAccountContext is like NewServiceAccount but takes a context for cancellation and deadlines.
+func (c *Client) NewServiceAccountContext(ctx context.Context, request CreateServiceAccountRequest) (*ServiceAccount, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	result := &ServiceAccount{}
+	err = c.request(ctx, "POST", "/api/serviceaccounts", nil, bytes.NewBuffer(data), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+func (c *Client) UpdateServiceAccount(id int64, request UpdateServiceAccountRequest) error {
+	return c.UpdateServiceAccountContext(context.Background(), id, request)
+}
+
+// UpdateServiceAccountContext is like UpdateServiceAccount but takes a context for cancellation and deadlines.
+func (c *Client) UpdateServiceAccountContext(ctx context.Context, id int64, request UpdateServiceAccountRequest) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PATCH", fmt.Sprintf("/api/serviceaccounts/%d", id), nil, bytes.NewBuffer(data), nil)
+}
+
+func (c *Client) DeleteServiceAccount(id int64) error {
+	return c.DeleteServiceAccountContext(context.Background(), id)
+}
+
+// DeleteServiceAccountContext is like DeleteServiceAccount but takes a context for cancellation and deadlines.
+func (c *Client) DeleteServiceAccountContext(ctx context.Context, id int64) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/serviceaccounts/%d", id), nil, nil, nil)
+}
+
+// ServiceAccountTokens lists the tokens of a service account
+func (c *Client) ServiceAccountTokens(serviceAccountID int64) ([]ServiceAccountToken, error) {
+	return c.ServiceAccountTokensContext(context.Background(), serviceAccountID)
+}
+
+// ServiceAccountTokensContext is like ServiceAccountTokens but takes a context for cancellation and deadlines.
+func (c *Client) ServiceAccountTokensContext(ctx context.Context, serviceAccountID int64) ([]ServiceAccountToken, error) {
+	tokens := make([]ServiceAccountToken, 0)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/serviceaccounts/%d/tokens", serviceAccountID), nil, nil, &tokens)
+	return tokens, err
+}
+
+// NewServiceAccountToken mints a token for a service account, use the returned
+// Key with WithServiceAccountToken or Client.WithToken
+func (c *Client) NewServiceAccountToken(serviceAccountID int64, request CreateServiceAccountTokenRequest) (*CreateServiceAccountTokenResponse, error) {
+	return c.NewServiceAccountTokenContext(context.Background(), serviceAccountID, request)
+}
+
+// NewServiceAccountTokenContext is like NewServiceAccountToken but takes a context for cancellation and deadlines.
+func (c *Client) NewServiceAccountTokenContext(ctx context.Context, serviceAccountID int64, request CreateServiceAccountTokenRequest) (*CreateServiceAccountTokenResponse, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	result := &CreateServiceAccountTokenResponse{}
+	err = c.request(ctx, "POST", fmt.Sprintf("/api/serviceaccounts/%d/tokens", serviceAccountID), nil, bytes.NewBuffer(data), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+// DeleteServiceAccountToken revokes a token of a service account
+func (c *Client) DeleteServiceAccountToken(serviceAccountID, tokenID int64) error {
+	return c.DeleteServiceAccountTokenContext(context.Background(), serviceAccountID, tokenID)
+}
+
+// DeleteServiceAccountTokenContext is like DeleteServiceAccountToken but takes a context for cancellation and deadlines.
+func (c *Client) DeleteServiceAccountTokenContext(ctx context.Context, serviceAccountID, tokenID int64) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/serviceaccounts/%d/tokens/%d", serviceAccountID, tokenID), nil, nil, nil)
+}
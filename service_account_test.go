@@ -0,0 +1,186 @@
+package gapi
+
+import (
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	serviceAccountJSON = `
+{
+  "id": 1,
+  "name": "grafana",
+  "login": "sa-grafana",
+  "orgId": 1,
+  "isDisabled": false,
+  "role": "Viewer",
+  "tokens": 0,
+  "avatarUrl": "/avatar/8ea890a677d6a223c591a1beea6ea9d2"
+}
+`
+	searchServiceAccountsJSON = `
+{
+  "totalCount": 1,
+  "serviceAccounts": [
+    {
+      "id": 1,
+      "name": "grafana",
+      "login": "sa-grafana",
+      "orgId": 1,
+      "isDisabled": false,
+      "role": "Viewer",
+      "tokens": 1,
+      "avatarUrl": "/avatar/8ea890a677d6a223c591a1beea6ea9d2"
+    }
+  ],
+  "page": 1,
+  "perPage": 10
+}
+`
+	updatedServiceAccountJSON = `{"id":1,"message":"Service account updated","name":"test"}`
+	deletedServiceAccountJSON = `{"message":"Service account deleted"}`
+
+	getServiceAccountTokensJSON = `
+[
+  {
+    "id": 1,
+    "name": "grafana",
+    "created": "2022-03-23T10:31:02Z",
+    "expiration": null,
+    "hasExpired": false
+  }
+]
+`
+	createdServiceAccountTokenJSON = `{"id":7,"name":"grafana","key":"eyJrIjoiVjFxTHZ6dGdPSjg5Um92MjN1RlhjMkNqYkZUbm9jYkwiLCJuIjoiZ3JhZmFuYSIsImlkIjoxfQ=="}`
+	deletedServiceAccountTokenJSON = `{"message":"API key deleted"}`
+)
+
+func TestSearchServiceAccounts(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, searchServiceAccountsJSON)
+	defer server.Close()
+
+	resp, err := client.SearchServiceAccounts("grafana", 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/serviceaccounts/search")
+
+	t.Log(pretty.PrettyFormat(resp))
+
+	if resp.TotalCount != 1 || len(resp.ServiceAccounts) != 1 || resp.ServiceAccounts[0].Login != "sa-grafana" {
+		t.Error("Not correctly parsing returned service accounts.")
+	}
+}
+
+func TestServiceAccount(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, serviceAccountJSON)
+	defer server.Close()
+
+	resp, err := client.ServiceAccount(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/serviceaccounts/1")
+
+	sa := ServiceAccount{
+		ID:        1,
+		Name:      "grafana",
+		Login:     "sa-grafana",
+		OrgID:     1,
+		Role:      "Viewer",
+		AvatarURL: "/avatar/8ea890a677d6a223c591a1beea6ea9d2",
+	}
+	if *resp != sa {
+		t.Error("Not correctly parsing returned service account.")
+	}
+}
+
+func TestNewServiceAccount(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 201, serviceAccountJSON)
+	defer server.Close()
+
+	resp, err := client.NewServiceAccount(CreateServiceAccountRequest{Name: "grafana", Role: "Viewer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("POST", "/api/serviceaccounts")
+	if resp.ID != 1 {
+		t.Error("Not correctly parsing created service account.")
+	}
+}
+
+func TestUpdateServiceAccount(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, updatedServiceAccountJSON)
+	defer server.Close()
+
+	disabled := true
+	err := client.UpdateServiceAccount(1, UpdateServiceAccountRequest{Name: "test", IsDisabled: &disabled})
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("PATCH", "/api/serviceaccounts/1")
+}
+
+func TestDeleteServiceAccount(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, deletedServiceAccountJSON)
+	defer server.Close()
+
+	err := client.DeleteServiceAccount(1)
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/serviceaccounts/1")
+}
+
+func TestServiceAccountTokens(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getServiceAccountTokensJSON)
+	defer server.Close()
+
+	tokens, err := client.ServiceAccountTokens(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/serviceaccounts/1/tokens")
+	if len(tokens) != 1 || tokens[0].Created == nil || tokens[0].Expiration != nil {
+		t.Error("Not correctly parsing returned service account tokens.")
+	}
+}
+
+func TestNewServiceAccountTokenAndSwitch(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, createdServiceAccountTokenJSON)
+	defer server.Close()
+
+	token, err := client.NewServiceAccountToken(1, CreateServiceAccountTokenRequest{Name: "grafana", SecondsToLive: 3600})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("POST", "/api/serviceaccounts/1/tokens")
+	if token.ID != 7 || token.Key == "" {
+		t.Fatal("Not correctly parsing created service account token.")
+	}
+
+	headerServer, _, rec := gapiRecorderTestTools(t, 200, getFoldersJSON)
+	defer headerServer.Close()
+	admin, err := New("admin:admin", headerServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := admin.WithToken(token.Key).Folders(); err != nil {
+		t.Fatal(err)
+	}
+	if auth := rec.last().Header.Get("Authorization"); auth != "Bearer "+token.Key {
+		t.Errorf("expected the scoped client to use the token, got %q", auth)
+	}
+}
+
+func TestDeleteServiceAccountToken(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, deletedServiceAccountTokenJSON)
+	defer server.Close()
+
+	err := client.DeleteServiceAccountToken(1, 7)
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/serviceaccounts/1/tokens/7")
+}
@@ -0,0 +1,192 @@
+package gapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+type Team struct {
+	Id          int64  `json:"id,omitempty"`
+	OrgId       int64  `json:"orgId,omitempty"`
+	Name        string `json:"name"`
+	Email       string `json:"email,omitempty"`
+	AvatarUrl   string `json:"avatarUrl,omitempty"`
+	MemberCount int64  `json:"memberCount,omitempty"`
+	Permission  int64  `json:"permission,omitempty"`
+}
+
+// SearchTeam is a page of teams returned by the team search
+type SearchTeam struct {
+	TotalCount int64  `json:"totalCount"`
+	Teams      []Team `json:"teams"`
+	Page       int64  `json:"page"`
+	PerPage    int64  `json:"perPage"`
+}
+
+type TeamMember struct {
+	OrgId      int64    `json:"orgId"`
+	TeamId     int64    `json:"teamId"`
+	UserId     int64    `json:"userId"`
+	Email      string   `json:"email"`
+	Login      string   `json:"login"`
+	AvatarUrl  string   `json:"avatarUrl"`
+	Permission int64    `json:"permission"`
+	Labels     []string `json:"labels,omitempty"`
+}
+
+// SearchTeams searches the teams of the current org by name, page starts at 1
+func (c *Client) SearchTeams(query string, page, perPage int64) (*SearchTeam, error) {
+	return c.SearchTeamsContext(context.Background(), query, page, perPage)
+}
+
+// SearchTeamsContext is like SearchTeams but takes a context for cancellation and deadlines.
+func (c *Client) SearchTeamsContext(ctx context.Context, query string, page, perPage int64) (*SearchTeam, error) {
+	params := url.Values{}
+	params.Add("query", query)
+	if page > 0 {
+		params.Add("page", strconv.FormatInt(page, 10))
+	}
+	if perPage > 0 {
+		params.Add("perpage", strconv.FormatInt(perPage, 10))
+	}
+	result := &SearchTeam{}
+	err := c.request(ctx, "GET", "/api/teams/search", params, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, err
+}
+
+func (c *Client) Team(id int64) (*Team, error) {
+	return c.TeamContext(context.Background(), id)
+}
+
+// TeamContext is like Team but takes a context for cancellation and deadlines.
+func (c *Client) TeamContext(ctx context.Context, id int64) (*Team, error) {
+	team := &Team{}
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/teams/%d", id), nil, nil, team)
+	if err != nil {
+		return nil, err
+	}
+	return team, err
+}
+
+func (c *Client) NewTeam(name, email string) (int64, error) {
+	return c.NewTeamContext(context.Background(), name, email)
+}
+
+// NewTeamContext is like NewTeam but takes a context for cancellation and deadlines.
+func (c *Client) NewTeamContext(ctx context.Context, name, email string) (int64, error) {
+	dataMap := map[string]string{
+		"name":  name,
+		"email": email,
+	}
+	data, err := json.Marshal(dataMap)
+	if err != nil {
+		return 0, err
+	}
+	tmp := struct {
+		Id int64 `json:"teamId"`
+	}{}
+	err = c.request(ctx, "POST", "/api/teams", nil, bytes.NewBuffer(data), &tmp)
+	return tmp.Id, err
+}
+
+func (c *Client) UpdateTeam(id int64, name, email string) error {
+	return c.UpdateTeamContext(context.Background(), id, name, email)
+}
+
+// UpdateTeamContext is like UpdateTeam but takes a context for cancellation and deadlines.
+func (c *Client) UpdateTeamContext(ctx context.Context, id int64, name, email string) error {
+	dataMap := map[string]string{
+		"name":  name,
+		"email": email,
+	}
+	data, err := json.Marshal(dataMap)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "PUT", fmt.Sprintf("/api/teams/%d", id), nil, bytes.NewBuffer(data), nil)
+}
+
+func (c *Client) DeleteTeam(id int64) error {
+	return c.DeleteTeamContext(context.Background(), id)
+}
+
+// DeleteTeamContext is like DeleteTeam but takes a context for cancellation and deadlines.
+func (c *Client) DeleteTeamContext(ctx context.Context, id int64) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/teams/%d", id), nil, nil, nil)
+}
+
+func (c *Client) TeamMembers(id int64) ([]TeamMember, error) {
+	return c.TeamMembersContext(context.Background(), id)
+}
+
+// TeamMembersContext is like TeamMembers but takes a context for cancellation and deadlines.
+func (c *Client) TeamMembersContext(ctx context.Context, id int64) ([]TeamMember, error) {
+	members := make([]TeamMember, 0)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/teams/%d/members", id), nil, nil, &members)
+	return members, err
+}
+
+func (c *Client) AddTeamMember(id, userId int64) error {
+	return c.AddTeamMemberContext(context.Background(), id, userId)
+}
+
+// AddTeamMemberContext is like AddTeamMember but takes a context for cancellation and deadlines.
+func (c *Client) AddTeamMemberContext(ctx context.Context, id, userId int64) error {
+	dataMap := map[string]int64{
+		"userId": userId,
+	}
+	data, err := json.Marshal(dataMap)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, "POST", fmt.Sprintf("/api/teams/%d/members", id), nil, bytes.NewBuffer(data), nil)
+}
+
+func (c *Client) RemoveTeamMember(id, userId int64) error {
+	return c.RemoveTeamMemberContext(context.Background(), id, userId)
+}
+
+// RemoveTeamMemberContext is like RemoveTeamMember but takes a context for cancellation and deadlines.
+func (c *Client) RemoveTeamMemberContext(ctx context.Context, id, userId int64) error {
+	return c.request(ctx, "DELETE", fmt.Sprintf("/api/teams/%d/members/%d", id, userId), nil, nil, nil)
+}
+
+func (c *Client) TeamPreferences(id int64) (*Preferences, error) {
+	return c.TeamPreferencesContext(context.Background(), id)
+}
+
+// TeamPreferencesContext is like TeamPreferences but takes a context for cancellation and deadlines.
+func (c *Client) TeamPreferencesContext(ctx context.Context, id int64) (*Preferences, error) {
+	preferences := &Preferences{}
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/teams/%d/preferences", id), nil, nil, preferences)
+	if err != nil {
+		return nil, err
+	}
+	return preferences, err
+}
+
+func (c *Client) UpdateTeamPreferences(id int64, preferences Preferences) error {
+	return c.UpdateTeamPreferencesContext(context.Background(), id, preferences)
+}
+
+// UpdateTeamPreferencesContext is like UpdateTeamPreferences but takes a context for cancellation and deadlines.
+func (c *Client) UpdateTeamPreferencesContext(ctx context.Context, id int64, preferences Preferences) error {
+	return c.sendPreferences(ctx, "PUT", fmt.Sprintf("/api/teams/%d/preferences", id), preferences)
+}
+
+// PatchTeamPreferences changes the preferences of the team that are set in preferences
+func (c *Client) PatchTeamPreferences(id int64, preferences Preferences) error {
+	return c.PatchTeamPreferencesContext(context.Background(), id, preferences)
+}
+
+// PatchTeamPreferencesContext is like PatchTeamPreferences but takes a context for cancellation and deadlines.
+func (c *Client) PatchTeamPreferencesContext(ctx context.Context, id int64, preferences Preferences) error {
+	return c.sendPreferences(ctx, "PATCH", fmt.Sprintf("/api/teams/%d/preferences", id), preferences)
+}
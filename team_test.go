@@ -0,0 +1,203 @@
+package gapi
+
+import (
+	"testing"
+
+	"github.com/gobs/pretty"
+)
+
+const (
+	searchTeamJSON = `
+{
+  "totalCount": 1,
+  "teams": [
+    {
+      "id": 1,
+      "orgId": 1,
+      "name": "MyTestTeam",
+      "email": "",
+      "avatarUrl": "/avatar/3f49c15916554246daa714b9bd0ee398",
+      "memberCount": 1
+    }
+  ],
+  "page": 1,
+  "perPage": 1000
+}
+`
+	getTeamJSON = `
+{
+  "id": 1,
+  "orgId": 1,
+  "name": "MyTestTeam",
+  "email": "",
+  "created": "2017-12-15T10:40:45+01:00",
+  "updated": "2017-12-15T10:40:45+01:00"
+}
+`
+	createdTeamJSON = `{"message":"Team created","teamId":2}`
+	updatedTeamJSON = `{"message":"Team updated"}`
+	deletedTeamJSON = `{"message":"Team deleted"}`
+
+	getTeamMembersJSON = `
+[
+  {
+    "orgId": 1,
+    "teamId": 1,
+    "userId": 3,
+    "email": "user1@email.com",
+    "login": "user1",
+    "avatarUrl": "/avatar/1b3c32f6386b0185c40d359cdc733a79",
+    "labels": []
+  }
+]
+`
+	addTeamMemberJSON    = `{"message":"Member added to Team"}`
+	removeTeamMemberJSON = `{"message":"Team Member removed"}`
+
+	getTeamPreferencesJSON    = `{"theme":"","homeDashboardId":0,"timezone":""}`
+	updateTeamPreferencesJSON = `{"message":"Preferences updated"}`
+)
+
+func TestSearchTeams(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, searchTeamJSON)
+	defer server.Close()
+
+	resp, err := client.SearchTeams("MyTestTeam", 1, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/teams/search")
+
+	t.Log(pretty.PrettyFormat(resp))
+
+	if resp.TotalCount != 1 || len(resp.Teams) != 1 {
+		t.Fatal("Not correctly parsing returned team search.")
+	}
+	team := Team{
+		Id:          1,
+		OrgId:       1,
+		Name:        "MyTestTeam",
+		AvatarUrl:   "/avatar/3f49c15916554246daa714b9bd0ee398",
+		MemberCount: 1,
+	}
+	if resp.Teams[0] != team {
+		t.Error("Not correctly parsing returned teams.")
+	}
+}
+
+func TestTeam(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getTeamJSON)
+	defer server.Close()
+
+	resp, err := client.Team(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/teams/1")
+
+	t.Log(pretty.PrettyFormat(resp))
+
+	if resp.Id != 1 || resp.Name != "MyTestTeam" {
+		t.Error("Not correctly parsing returned team.")
+	}
+}
+
+func TestNewTeam(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, createdTeamJSON)
+	defer server.Close()
+
+	id, err := client.NewTeam("MyTestTeam", "email@test.com")
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("POST", "/api/teams")
+	if id != 2 {
+		t.Error("Not correctly parsing returned team id.")
+	}
+}
+
+func TestUpdateTeam(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, updatedTeamJSON)
+	defer server.Close()
+
+	err := client.UpdateTeam(1, "MyTestTeam", "email@test.com")
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/teams/1")
+}
+
+func TestDeleteTeam(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, deletedTeamJSON)
+	defer server.Close()
+
+	err := client.DeleteTeam(1)
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/teams/1")
+}
+
+func TestTeamMembers(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getTeamMembersJSON)
+	defer server.Close()
+
+	resp, err := client.TeamMembers(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/teams/1/members")
+
+	t.Log(pretty.PrettyFormat(resp))
+
+	if len(resp) != 1 || resp[0].UserId != 3 || resp[0].Login != "user1" {
+		t.Error("Not correctly parsing returned team members.")
+	}
+}
+
+func TestAddTeamMember(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, addTeamMemberJSON)
+	defer server.Close()
+
+	err := client.AddTeamMember(1, 3)
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("POST", "/api/teams/1/members")
+}
+
+func TestRemoveTeamMember(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, removeTeamMemberJSON)
+	defer server.Close()
+
+	err := client.RemoveTeamMember(1, 3)
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("DELETE", "/api/teams/1/members/3")
+}
+
+func TestTeamPreferences(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getTeamPreferencesJSON)
+	defer server.Close()
+
+	resp, err := client.TeamPreferences(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/teams/1/preferences")
+	if *resp != (Preferences{}) {
+		t.Error("Not correctly parsing returned team preferences.")
+	}
+}
+
+func TestUpdateTeamPreferences(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, updateTeamPreferencesJSON)
+	defer server.Close()
+
+	err := client.UpdateTeamPreferences(1, Preferences{Theme: "dark", Timezone: "utc"})
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("PUT", "/api/teams/1/preferences")
+}
@@ -1,76 +1,252 @@
 package gapi
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
+	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 )
 
+// User is a Grafana user, IsAdmin is the Grafana admin permission which
+// some endpoints return as isGrafanaAdmin
 type User struct {
-	Id       int64  `json:"id,omitempty"`
-	Email    string `json:"email,omitempty"`
-	Name     string `json:"name,omitempty"`
-	Login    string `json:"login,omitempty"`
-	Password string `json:"password,omitempty"`
-	IsAdmin  bool   `json:"isAdmin,omitempty"`
+	Id         int64      `json:"id,omitempty"`
+	Email      string     `json:"email,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	Login      string     `json:"login,omitempty"`
+	Password   string     `json:"password,omitempty"`
+	IsAdmin    bool       `json:"isAdmin,omitempty"`
+	OrgId      int64      `json:"orgId,omitempty"`
+	Theme      string     `json:"theme,omitempty"`
+	IsDisabled bool       `json:"isDisabled,omitempty"`
+	IsExternal bool       `json:"isExternal,omitempty"`
+	AvatarUrl  string     `json:"avatarUrl,omitempty"`
+	AuthLabels []string   `json:"authLabels,omitempty"`
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
 }
 
-func (c *Client) Users() ([]User, error) {
-	users := make([]User, 0)
-	req, err := c.newRequest("GET", "/api/users", nil, nil)
-	if err != nil {
-		return users, err
-	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return users, err
+// UnmarshalJSON accepts the Grafana admin permission as isAdmin and isGrafanaAdmin
+func (u *User) UnmarshalJSON(data []byte) error {
+	type user User
+	raw := struct {
+		*user
+		IsGrafanaAdmin *bool `json:"isGrafanaAdmin"`
+	}{user: (*user)(u)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
 	}
-	if resp.StatusCode != 200 {
-		return users, errors.New(resp.Status)
-	}
-	data, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return users, err
-	}
-	err = json.Unmarshal(data, &users)
-	if err != nil {
-		return users, err
+	if raw.IsGrafanaAdmin != nil {
+		u.IsAdmin = *raw.IsGrafanaAdmin
 	}
+	return nil
+}
+
+// UserOrg is an organization the user is a member of
+type UserOrg struct {
+	OrgId int64  `json:"orgId"`
+	Name  string `json:"name"`
+	Role  string `json:"role"`
+}
+
+// SearchUser is a page of the user search
+type SearchUser struct {
+	TotalCount int64  `json:"totalCount"`
+	Users      []User `json:"users"`
+	Page       int64  `json:"page"`
+	PerPage    int64  `json:"perPage"`
+}
+
+func (c *Client) Users() ([]User, error) {
+	return c.UsersContext(context.Background())
+}
+
+// UsersContext is like Users but takes a context for cancellation and deadlines.
+func (c *Client) UsersContext(ctx context.Context) ([]User, error) {
+	users := make([]User, 0)
+	err := c.request(ctx, "GET", "/api/users", nil, nil, &users)
 	return users, err
 }
 
+// User returns the user with the given id
+func (c *Client) User(id int64) (User, error) {
+	return c.UserContext(context.Background(), id)
+}
+
+// UserContext is like User but takes a context for cancellation and deadlines.
+func (c *Client) UserContext(ctx context.Context, id int64) (User, error) {
+	user := User{}
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/users/%d", id), nil, nil, &user)
+	return user, err
+}
+
 func (c *Client) UserByEmail(email string) (User, error) {
+	return c.UserByEmailContext(context.Background(), email)
+}
+
+// UserByEmailContext is like UserByEmail but takes a context for cancellation and deadlines.
+func (c *Client) UserByEmailContext(ctx context.Context, email string) (User, error) {
+	return c.userLookup(ctx, email)
+}
+
+// UserByLogin returns the user with the given login
+func (c *Client) UserByLogin(login string) (User, error) {
+	return c.UserByLoginContext(context.Background(), login)
+}
+
+// UserByLoginContext is like UserByLogin but takes a context for cancellation and deadlines.
+func (c *Client) UserByLoginContext(ctx context.Context, login string) (User, error) {
+	return c.userLookup(ctx, login)
+}
+
+func (c *Client) userLookup(ctx context.Context, loginOrEmail string) (User, error) {
 	user := User{}
 	query := url.Values{}
-	query.Add("loginOrEmail", email)
-	req, err := c.newRequest("GET", "/api/users/lookup", query, nil)
-	if err != nil {
-		return user, err
+	query.Add("loginOrEmail", loginOrEmail)
+	err := c.request(ctx, "GET", "/api/users/lookup", query, nil, &user)
+	return user, err
+}
+
+// SearchUsers searches the users by login, email or name, page starts at 1
+func (c *Client) SearchUsers(query string, page, perPage int64) (*SearchUser, error) {
+	return c.SearchUsersContext(context.Background(), query, page, perPage)
+}
+
+// SearchUsersContext is like SearchUsers but takes a context for cancellation and deadlines.
+func (c *Client) SearchUsersContext(ctx context.Context, query string, page, perPage int64) (*SearchUser, error) {
+	params := url.Values{}
+	if query != "" {
+		params.Add("query", query)
 	}
-	resp, err := c.Do(req)
-	if err != nil {
-		return user, err
+	if page > 0 {
+		params.Add("page", strconv.FormatInt(page, 10))
 	}
-	if resp.StatusCode != 200 {
-		return user, errors.New(resp.Status)
+	if perPage > 0 {
+		params.Add("perpage", strconv.FormatInt(perPage, 10))
 	}
-	data, err := ioutil.ReadAll(resp.Body)
+	result := &SearchUser{}
+	err := c.request(ctx, "GET", "/api/users/search", params, nil, result)
 	if err != nil {
-		return user, err
+		return nil, err
 	}
-	tmp := struct {
-		Id       int64  `json:"id,omitempty"`
-		Email    string `json:"email,omitempty"`
-		Name     string `json:"name,omitempty"`
-		Login    string `json:"login,omitempty"`
-		Password string `json:"password,omitempty"`
-		IsAdmin  bool   `json:"isGrafanaAdmin,omitempty"`
-	}{}
-	err = json.Unmarshal(data, &tmp)
+	return result, err
+}
+
+// UserIterator pages through all users matching a search
+//
+//	it := client.NewUserIterator("admin", 0)
+//	for it.Next() {
+//		user := it.User()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type UserIterator struct {
+	pager
+	users []User
+}
+
+// NewUserIterator returns an iterator over all users matching query,
+// perPage is the page size, 1000 if not set
+func (c *Client) NewUserIterator(query string, perPage int64) *UserIterator {
+	return c.NewUserIteratorContext(context.Background(), query, perPage)
+}
+
+// NewUserIteratorContext is like NewUserIterator but takes a context for cancellation and deadlines.
+func (c *Client) NewUserIteratorContext(ctx context.Context, query string, perPage int64) *UserIterator {
+	if perPage <= 0 {
+		perPage = defaultSearchLimit
+	}
+	it := &UserIterator{}
+	it.pager = newPager(1, perPage, func(page int64) (int, error) {
+		result, err := c.SearchUsersContext(ctx, query, page, perPage)
+		if err != nil {
+			return 0, err
+		}
+		it.users = result.Users
+		return len(result.Users), nil
+	})
+	return it
+}
+
+// Next advances to the next user, it returns false when all users have been
+// read or an error occurred
+func (it *UserIterator) Next() bool {
+	return it.next()
+}
+
+// User returns the current user
+func (it *UserIterator) User() User {
+	return it.users[it.i]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *UserIterator) Err() error {
+	return it.err
+}
+
+// UpdateUser updates the email, name, login and theme of the user with the id
+// of user. Empty fields are left unchanged, they are filled from the current
+// user as Grafana would otherwise replace an empty login with the email.
+func (c *Client) UpdateUser(user User) error {
+	return c.UpdateUserContext(context.Background(), user)
+}
+
+// UpdateUserContext is like UpdateUser but takes a context for cancellation and deadlines.
+func (c *Client) UpdateUserContext(ctx context.Context, user User) error {
+	if user.Email == "" || user.Name == "" || user.Login == "" || user.Theme == "" {
+		existing, err := c.UserContext(ctx, user.Id)
+		if err != nil {
+			return err
+		}
+		if user.Email == "" {
+			user.Email = existing.Email
+		}
+		if user.Name == "" {
+			user.Name = existing.Name
+		}
+		if user.Login == "" {
+			user.Login = existing.Login
+		}
+		if user.Theme == "" {
+			user.Theme = existing.Theme
+		}
+	}
+	data, err := json.Marshal(map[string]string{
+		"email": user.Email,
+		"name":  user.Name,
+		"login": user.Login,
+		"theme": user.Theme,
+	})
 	if err != nil {
-		return user, err
+		return err
 	}
-	user = User(tmp)
-	return user, err
+	return c.request(ctx, "PUT", fmt.Sprintf("/api/users/%d", user.Id), nil, bytes.NewBuffer(data), nil)
+}
+
+// UserOrgs lists the organizations of the user with the given id
+func (c *Client) UserOrgs(id int64) ([]UserOrg, error) {
+	return c.UserOrgsContext(context.Background(), id)
+}
+
+// UserOrgsContext is like UserOrgs but takes a context for cancellation and deadlines.
+func (c *Client) UserOrgsContext(ctx context.Context, id int64) ([]UserOrg, error) {
+	orgs := make([]UserOrg, 0)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/users/%d/orgs", id), nil, nil, &orgs)
+	return orgs, err
+}
+
+// UserTeams lists the teams of the user with the given id
+func (c *Client) UserTeams(id int64) ([]Team, error) {
+	return c.UserTeamsContext(context.Background(), id)
+}
+
+// UserTeamsContext is like UserTeams but takes a context for cancellation and deadlines.
+func (c *Client) UserTeamsContext(ctx context.Context, id int64) ([]Team, error) {
+	teams := make([]Team, 0)
+	err := c.request(ctx, "GET", fmt.Sprintf("/api/users/%d/teams", id), nil, nil, &teams)
+	return teams, err
 }
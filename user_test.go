@@ -1,13 +1,24 @@
 package gapi
 
 import (
-	"github.com/gobs/pretty"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/gobs/pretty"
 )
 
 const (
 	getUsersJSON       = `[{"id":1,"name":"","login":"admin","email":"admin@localhost","avatarUrl":"/avatar/46d229b033af06a191ff2267bca9ae56","isAdmin":true,"lastSeenAt":"2018-06-28T14:42:24Z","lastSeenAtAge":"\u003c 1m"}]`
 	getUserByEmailJSON = `{"id":1,"email":"admin@localhost","name":"","login":"admin","theme":"","orgId":1,"isGrafanaAdmin":true}`
+	getUserJSON        = `{"id":2,"email":"jane@localhost","name":"Jane","login":"jane","theme":"light","orgId":1,"isGrafanaAdmin":false,"isDisabled":true,"isExternal":true,"authLabels":["OAuth"],"updatedAt":"2019-09-09T11:31:26+02:00","createdAt":"2019-09-09T11:31:26+02:00","avatarUrl":""}`
+	searchUsersJSON    = `{"totalCount":3,"users":[{"id":1,"login":"admin","isAdmin":true},{"id":2,"login":"jane","isDisabled":true}],"page":1,"perPage":2}`
+	getUserTeamsJSON   = `[{"id":1,"orgId":1,"name":"MyTestTeam","email":"","avatarUrl":"/avatar/3f49c15916554246daa714b9bd0ee398","memberCount":1}]`
+	getUserOrgsJSON    = `[{"orgId":1,"name":"Main Org.","role":"Admin"},{"orgId":2,"name":"Team","role":"Viewer"}]`
 )
 
 func TestUsers(t *testing.T) {
@@ -21,15 +32,18 @@ func TestUsers(t *testing.T) {
 
 	t.Log(pretty.PrettyFormat(resp))
 
+	lastSeenAt := time.Date(2018, 6, 28, 14, 42, 24, 0, time.UTC)
 	user := User{
-		Id:      1,
-		Email:   "admin@localhost",
-		Name:    "",
-		Login:   "admin",
-		IsAdmin: true,
+		Id:         1,
+		Email:      "admin@localhost",
+		Name:       "",
+		Login:      "admin",
+		IsAdmin:    true,
+		AvatarUrl:  "/avatar/46d229b033af06a191ff2267bca9ae56",
+		LastSeenAt: &lastSeenAt,
 	}
 
-	if len(resp) != 1 || resp[0] != user {
+	if len(resp) != 1 || !reflect.DeepEqual(resp[0], user) {
 		t.Error("Not correctly parsing returned users.")
 	}
 }
@@ -51,8 +65,158 @@ func TestUserByEmail(t *testing.T) {
 		Name:    "",
 		Login:   "admin",
 		IsAdmin: true,
+		OrgId:   1,
+	}
+	if !reflect.DeepEqual(resp, user) {
+		t.Error("Not correctly parsing returned user.")
+	}
+}
+
+func TestUserOrgs(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getUserOrgsJSON)
+	defer server.Close()
+
+	orgs, err := client.UserOrgs(1)
+	if err != nil {
+		t.Error(err)
+	}
+	rec.expect("GET", "/api/users/1/orgs")
+
+	t.Log(pretty.PrettyFormat(orgs))
+
+	expected := UserOrg{OrgId: 2, Name: "Team", Role: "Viewer"}
+	if len(orgs) != 2 || orgs[1] != expected {
+		t.Error("Not correctly parsing returned user orgs.")
 	}
-	if resp != user {
+}
+
+func TestUser(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getUserJSON)
+	defer server.Close()
+
+	user, err := client.User(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/users/2")
+
+	t.Log(pretty.PrettyFormat(user))
+
+	if user.Login != "jane" || user.Theme != "light" || !user.IsDisabled || !user.IsExternal || user.IsAdmin {
+		t.Error("Not correctly parsing returned user.")
+	}
+	if !reflect.DeepEqual(user.AuthLabels, []string{"OAuth"}) || user.CreatedAt == nil || user.CreatedAt.Hour() != 11 {
+		t.Error("Not correctly parsing the auth labels and timestamps of the user.")
+	}
+}
+
+func TestUserByLogin(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getUserByEmailJSON)
+	defer server.Close()
+
+	user, err := client.UserByLogin("admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q := rec.expect("GET", "/api/users/lookup").Query; q.Get("loginOrEmail") != "admin" {
+		t.Errorf("unexpected lookup query %v", q)
+	}
+	if user.Id != 1 || !user.IsAdmin {
 		t.Error("Not correctly parsing returned user.")
 	}
 }
+
+func TestSearchUsers(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, searchUsersJSON)
+	defer server.Close()
+
+	result, err := client.SearchUsers("a", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q := rec.expect("GET", "/api/users/search").Query; q.Get("query") != "a" || q.Get("page") != "1" || q.Get("perpage") != "2" {
+		t.Errorf("unexpected search query %v", q)
+	}
+	if result.TotalCount != 3 || len(result.Users) != 2 || !result.Users[0].IsAdmin || !result.Users[1].IsDisabled {
+		t.Error("Not correctly parsing returned user search.")
+	}
+}
+
+func TestUserIterator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"totalCount":3,"users":[{"id":1},{"id":2}],"page":1,"perPage":2}`)
+		case "2":
+			fmt.Fprint(w, `{"totalCount":3,"users":[{"id":3}],"page":2,"perPage":2}`)
+		default:
+			t.Errorf("unexpected request for page %s", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New("my-key", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int64
+	it := client.NewUserIterator("", 2)
+	for it.Next() {
+		ids = append(ids, it.User().Id)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []int64{1, 2, 3}) {
+		t.Errorf("expected to iterate over all users, got %v", ids)
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, `{"message":"User updated"}`)
+	defer server.Close()
+	rec.respond("GET", "/api/users/2", 200, getUserJSON)
+
+	err := client.UpdateUser(User{Id: 2, Email: "jane@localhost", Name: "Jane", Login: "jane", Theme: "dark"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.all()) != 1 {
+		t.Errorf("expected no lookup if all fields are set, got %v", rec.all())
+	}
+	rec.expect("PUT", "/api/users/2")
+
+	existing, err := client.User(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.UpdateUser(User{Id: 2, Theme: "dark"}); err != nil {
+		t.Fatal(err)
+	}
+	updated := map[string]string{}
+	if err := json.Unmarshal([]byte(rec.expect("PUT", "/api/users/2").Body), &updated); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"email": existing.Email, "name": existing.Name, "login": existing.Login, "theme": "dark"}
+	if !reflect.DeepEqual(updated, expected) {
+		t.Errorf("expected the empty fields to be kept, got %v", updated)
+	}
+}
+
+func TestUserTeams(t *testing.T) {
+	server, client, rec := gapiRecorderTestTools(t, 200, getUserTeamsJSON)
+	defer server.Close()
+
+	teams, err := client.UserTeams(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.expect("GET", "/api/users/1/teams")
+
+	if len(teams) != 1 || teams[0].Name != "MyTestTeam" || teams[0].MemberCount != 1 {
+		t.Error("Not correctly parsing returned user teams.")
+	}
+}
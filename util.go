@@ -65,3 +65,44 @@ func buildPathAndQuery(path string, data map[string]string) string {
 
 	return pathAndQuery + params.Encode()
 }
+
+// pager drives the iterators over paged API results, fetch loads the given
+// page, starting at 1, and returns the number of items it contains
+type pager struct {
+	page    int64
+	perPage int64
+	n, i    int
+	done    bool
+	err     error
+	fetch   func(page int64) (int, error)
+}
+
+func newPager(page, perPage int64, fetch func(page int64) (int, error)) pager {
+	if page < 1 {
+		page = 1
+	}
+	return pager{page: page - 1, perPage: perPage, fetch: fetch}
+}
+
+// next advances to the next item, fetching the next page if required
+func (p *pager) next() bool {
+	if p.err != nil {
+		return false
+	}
+	p.i++
+	if p.i < p.n {
+		return true
+	}
+	if p.done {
+		return false
+	}
+	p.page++
+	n, err := p.fetch(p.page)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	p.n, p.i = n, 0
+	p.done = int64(n) < p.perPage
+	return n > 0
+}